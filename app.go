@@ -3,19 +3,37 @@ package main
 import (
 	"context"
 
+	"github.com/lanxre/mc-launcher/backend/operations"
 	"github.com/wailsapp/wails/v2/pkg/runtime"
 )
 
 type App struct {
 	ctx context.Context
+	ops *operations.Registry
 }
 
-func NewApp() *App {
-	return &App{}
+func NewApp(ops *operations.Registry) *App {
+	return &App{ops: ops}
 }
 
 func (a *App) startup(ctx context.Context) {
 	a.ctx = ctx
+	a.ops.Bind(ctx)
+}
+
+func (a *App) shutdown(ctx context.Context) {
+	a.ops.CancelAll()
+}
+
+// emit sends an event to the frontend. Events before startup are dropped.
+func (a *App) emit(name string, data ...interface{}) {
+	if a.ctx != nil {
+		runtime.EventsEmit(a.ctx, name, data...)
+	}
+}
+
+func (a *App) CancelOperation(opID string) bool {
+	return a.ops.Cancel(opID)
 }
 
 func (a *App) OpenExternalLink(url string) {
@@ -29,3 +47,20 @@ func (a *App) ShowInfoMessage(title, message string) {
 		Message: message,
 	})
 }
+
+// AskQuestion shows a yes/no dialog and reports whether the user answered
+// yes.
+func (a *App) AskQuestion(title, message string) (bool, error) {
+	answer, err := runtime.MessageDialog(a.ctx, runtime.MessageDialogOptions{
+		Type:          runtime.QuestionDialog,
+		Title:         title,
+		Message:       message,
+		Buttons:       []string{"Yes", "No"},
+		DefaultButton: "Yes",
+		CancelButton:  "No",
+	})
+	if err != nil {
+		return false, err
+	}
+	return answer == "Yes", nil
+}
@@ -0,0 +1,24 @@
+package appdata
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const appDirName = "mc-launcher"
+
+// Dir returns the launcher's own data directory, or a subdirectory of it,
+// creating it if needed.
+func Dir(sub ...string) (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get config dir: %w", err)
+	}
+
+	dir := filepath.Join(append([]string{configDir, appDirName}, sub...)...)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create data dir: %w", err)
+	}
+	return dir, nil
+}
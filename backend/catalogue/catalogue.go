@@ -0,0 +1,300 @@
+package catalogue
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/lanxre/mc-launcher/backend/operations"
+	"github.com/lanxre/mc-launcher/backend/parser"
+)
+
+const (
+	// searchPageSize is the page size of local search results.
+	searchPageSize = 20
+	// savePages is how many crawled pages are kept in memory between saves.
+	savePages = 5
+)
+
+// pageLister is the part of parser.ScraperService the crawler needs.
+type pageLister interface {
+	GetSources() []parser.SourceInfo
+	GetSourceModsByPage(opID, sourceID string, page int, inputSearch *string) (parser.ModsPage, error)
+}
+
+type CrawlStatus struct {
+	Source     string    `yaml:"source"`
+	Running    bool      `yaml:"running"`
+	NextPage   int       `yaml:"next_page"`
+	TotalPages int       `yaml:"total_pages"`
+	Mods       int       `yaml:"mods"`
+	Complete   bool      `yaml:"complete"`
+	UpdatedAt  time.Time `yaml:"updated_at"`
+	Error      string    `yaml:"error"`
+}
+
+// catalogue is the in-memory copy of one source's crawled mods.
+type catalogue struct {
+	snap       snapshot
+	positions  map[string]int // mod page link -> index in snap.Mods
+	index      *index         // nil until the next search after a change
+	version    int            // bumped by every merge
+	running    bool
+	stopping   atomic.Bool
+	totalPages int
+	err        string
+}
+
+func (c *catalogue) merge(mods []parser.MinecraftMod) {
+	for _, mod := range mods {
+		if mod.ModPageLink == "" {
+			continue
+		}
+		if i, ok := c.positions[mod.ModPageLink]; ok {
+			c.snap.Mods[i] = mod
+			continue
+		}
+		c.positions[mod.ModPageLink] = len(c.snap.Mods)
+		c.snap.Mods = append(c.snap.Mods, mod)
+	}
+	c.index = nil
+	c.version++
+}
+
+// CatalogueService crawls the mod lists of the sources in the background
+// and searches the stored mods offline.
+type CatalogueService struct {
+	lister pageLister
+	ops    *operations.Registry
+
+	mu         sync.Mutex
+	catalogues map[string]*catalogue
+}
+
+func NewCatalogueService(lister pageLister, ops *operations.Registry) *CatalogueService {
+	return &CatalogueService{lister: lister, ops: ops, catalogues: make(map[string]*catalogue)}
+}
+
+// StartCrawl starts walking the mod list of sourceID in the background,
+// resuming where the last crawl stopped. It does nothing if a crawl of
+// that source is already running.
+func (s *CatalogueService) StartCrawl(sourceID string) error {
+	sourceID = s.sourceID(sourceID)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, err := s.catalogue(sourceID)
+	if err != nil {
+		return err
+	}
+	if c.running {
+		return nil
+	}
+
+	c.running = true
+	c.stopping.Store(false)
+	c.err = ""
+	go s.crawl(sourceID, c)
+	return nil
+}
+
+// StopCrawl stops the crawl of sourceID after saving what it has fetched.
+func (s *CatalogueService) StopCrawl(sourceID string) bool {
+	sourceID = s.sourceID(sourceID)
+
+	s.mu.Lock()
+	c, ok := s.catalogues[sourceID]
+	running := ok && c.running
+	s.mu.Unlock()
+
+	if running {
+		c.stopping.Store(true)
+		s.ops.Cancel(crawlOpID(sourceID))
+	}
+	return running
+}
+
+func (s *CatalogueService) GetCrawlStatus(sourceID string) (CrawlStatus, error) {
+	sourceID = s.sourceID(sourceID)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, err := s.catalogue(sourceID)
+	if err != nil {
+		return CrawlStatus{Source: sourceID}, err
+	}
+	return CrawlStatus{
+		Source:     sourceID,
+		Running:    c.running,
+		NextPage:   c.snap.NextPage,
+		TotalPages: c.totalPages,
+		Mods:       len(c.snap.Mods),
+		Complete:   c.snap.NextPage == 0 && !c.snap.UpdatedAt.IsZero(),
+		UpdatedAt:  c.snap.UpdatedAt,
+		Error:      c.err,
+	}, nil
+}
+
+// SearchLocal searches the crawled mods of sourceID. Words match by prefix
+// and with small typos, in either Cyrillic or Latin spelling.
+func (s *CatalogueService) SearchLocal(sourceID string, query parser.SearchQuery) (parser.ModsPage, error) {
+	idx, err := s.searchIndex(s.sourceID(sourceID))
+	if err != nil {
+		return parser.ModsPage{}, err
+	}
+
+	scores := idx.search(query.Text)
+	ids := make([]int, 0, len(scores))
+	for id := range scores {
+		if query.Matches(idx.mods[id]) {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+	slices.SortStableFunc(ids, resultOrder(query.Sort, idx.mods, scores))
+
+	page := max(query.Page, 1)
+	start := min((page-1)*searchPageSize, len(ids))
+	end := min(start+searchPageSize, len(ids))
+
+	items := make([]parser.MinecraftMod, 0, end-start)
+	for _, id := range ids[start:end] {
+		items = append(items, idx.mods[id])
+	}
+	return parser.ModsPage{Items: items, Pagination: parser.NewPagination(page, searchPageSize, len(ids))}, nil
+}
+
+// searchIndex returns the index of sourceID, building it if the catalogue
+// changed. The index is built from a copy of the mods without holding s.mu,
+// so searches never stall a crawl, and is read-only once built.
+func (s *CatalogueService) searchIndex(sourceID string) (*index, error) {
+	s.mu.Lock()
+	c, err := s.catalogue(sourceID)
+	if err != nil {
+		s.mu.Unlock()
+		return nil, err
+	}
+	if c.index != nil {
+		idx := c.index
+		s.mu.Unlock()
+		return idx, nil
+	}
+	mods := slices.Clone(c.snap.Mods)
+	version := c.version
+	s.mu.Unlock()
+
+	idx := newIndex(mods)
+
+	s.mu.Lock()
+	if c.version == version {
+		c.index = idx
+	}
+	s.mu.Unlock()
+	return idx, nil
+}
+
+// resultOrder compares two search results for the given sort order.
+func resultOrder(order parser.SortOrder, mods []parser.MinecraftMod, scores map[int]float64) func(a, b int) int {
+	newer := func(a, b time.Time) int { return b.Compare(a) }
+	switch order {
+	case parser.SortDownloads, parser.SortPopular:
+		return func(a, b int) int { return cmp.Compare(mods[b].Downloads, mods[a].Downloads) }
+	case parser.SortNewest:
+		return func(a, b int) int { return newer(mods[a].Published, mods[b].Published) }
+	case parser.SortUpdated:
+		return func(a, b int) int { return newer(mods[a].Updated, mods[b].Updated) }
+	}
+	return func(a, b int) int { return cmp.Compare(scores[b], scores[a]) }
+}
+
+func (s *CatalogueService) crawl(sourceID string, c *catalogue) {
+	opID := crawlOpID(sourceID)
+	page := max(c.snap.NextPage, 1)
+
+	for fetched := 1; !c.stopping.Load(); fetched++ {
+		result, err := s.lister.GetSourceModsByPage(opID, sourceID, page, nil)
+		if err != nil {
+			if !errors.Is(err, operations.ErrCancelled) {
+				log.Printf("catalogue crawl of %s stopped at page %d: %v", sourceID, page, err)
+				s.mu.Lock()
+				c.err = err.Error()
+				s.mu.Unlock()
+			}
+			break
+		}
+
+		s.mu.Lock()
+		c.merge(result.Items)
+		c.totalPages = result.TotalPages
+		c.snap.NextPage = page + 1
+		if !result.HasNext {
+			c.snap.NextPage = 0
+		}
+		c.snap.UpdatedAt = time.Now()
+		s.mu.Unlock()
+
+		if c.snap.NextPage == 0 {
+			break
+		}
+		if fetched%savePages == 0 {
+			s.save(c)
+		}
+		page++
+	}
+
+	s.save(c)
+	s.mu.Lock()
+	c.running = false
+	s.mu.Unlock()
+}
+
+func (s *CatalogueService) save(c *catalogue) {
+	s.mu.Lock()
+	snap := c.snap
+	snap.Mods = slices.Clone(c.snap.Mods)
+	s.mu.Unlock()
+
+	if err := saveSnapshot(snap); err != nil {
+		log.Printf("failed to save catalogue of %s: %v", snap.Source, err)
+	}
+}
+
+// catalogue returns the catalogue of sourceID, loading it from disk the
+// first time. The caller must hold s.mu.
+func (s *CatalogueService) catalogue(sourceID string) (*catalogue, error) {
+	if c, ok := s.catalogues[sourceID]; ok {
+		return c, nil
+	}
+
+	snap, err := loadSnapshot(sourceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load catalogue of %s: %w", sourceID, err)
+	}
+
+	c := &catalogue{snap: snapshot{Source: sourceID, NextPage: snap.NextPage, UpdatedAt: snap.UpdatedAt}, positions: make(map[string]int)}
+	c.merge(snap.Mods)
+	s.catalogues[sourceID] = c
+	return c, nil
+}
+
+// sourceID resolves an empty ID to the default source, like the scraper
+// service does.
+func (s *CatalogueService) sourceID(id string) string {
+	if id == "" {
+		if sources := s.lister.GetSources(); len(sources) > 0 {
+			return sources[0].ID
+		}
+	}
+	return id
+}
+
+func crawlOpID(sourceID string) string {
+	return "catalogue-crawl-" + sourceID
+}
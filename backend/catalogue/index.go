@@ -0,0 +1,235 @@
+package catalogue
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/lanxre/mc-launcher/backend/parser"
+)
+
+// Field weights: a hit in the name counts more than one in the tags, which
+// counts more than one in the description.
+const (
+	nameWeight        = 3
+	tagWeight         = 2
+	descriptionWeight = 1
+)
+
+// Scores of a query word against an indexed word.
+const (
+	exactScore  = 1.0
+	prefixScore = 0.8
+	fuzzyScore  = 0.6
+)
+
+// index is an inverted index over mod names, tags and descriptions. Words
+// are stored lowercased and transliterated to Latin.
+type index struct {
+	mods     []parser.MinecraftMod
+	postings map[string]map[int]float64
+	words    []string         // sorted vocabulary, for prefix lookups
+	grams    map[string][]int // bigram -> positions in words, for fuzzy lookups
+}
+
+func newIndex(mods []parser.MinecraftMod) *index {
+	idx := &index{mods: mods, postings: make(map[string]map[int]float64)}
+	for id, mod := range mods {
+		idx.add(id, mod.Name, nameWeight)
+		for _, tag := range append(slices.Clone(mod.Categories), mod.Loaders...) {
+			idx.add(id, tag, tagWeight)
+		}
+		idx.add(id, mod.Description, descriptionWeight)
+	}
+
+	idx.words = make([]string, 0, len(idx.postings))
+	for word := range idx.postings {
+		idx.words = append(idx.words, word)
+	}
+	sort.Strings(idx.words)
+
+	idx.grams = make(map[string][]int)
+	for i, word := range idx.words {
+		for _, gram := range bigrams(word) {
+			idx.grams[gram] = append(idx.grams[gram], i)
+		}
+	}
+	return idx
+}
+
+func (idx *index) add(id int, text string, weight float64) {
+	for _, word := range tokenize(text) {
+		docs := idx.postings[word]
+		if docs == nil {
+			docs = make(map[int]float64)
+			idx.postings[word] = docs
+		}
+		docs[id] = max(docs[id], weight)
+	}
+}
+
+// search scores the mods containing every word of text, allowing prefix
+// and fuzzy matches. An empty text matches every mod with score 0.
+func (idx *index) search(text string) map[int]float64 {
+	words := tokenize(text)
+	scores := make(map[int]float64)
+	if len(words) == 0 {
+		for id := range idx.mods {
+			scores[id] = 0
+		}
+		return scores
+	}
+
+	for i, word := range words {
+		hits := idx.lookup(word)
+		if i == 0 {
+			scores = hits
+			continue
+		}
+		for id, score := range scores {
+			if hit, ok := hits[id]; ok {
+				scores[id] = score + hit
+			} else {
+				delete(scores, id)
+			}
+		}
+	}
+	return scores
+}
+
+// lookup returns the best score of word for every mod it matches.
+func (idx *index) lookup(word string) map[int]float64 {
+	hits := make(map[int]float64)
+	merge := func(term string, score float64) {
+		for id, weight := range idx.postings[term] {
+			hits[id] = max(hits[id], weight*score)
+		}
+	}
+
+	merge(word, exactScore)
+
+	start := sort.SearchStrings(idx.words, word)
+	for _, term := range idx.words[start:] {
+		if !strings.HasPrefix(term, word) {
+			break
+		}
+		if term != word {
+			merge(term, prefixScore)
+		}
+	}
+
+	if limit := fuzzyLimit(word); limit > 0 {
+		for _, term := range idx.fuzzyCandidates(word, limit) {
+			if strings.HasPrefix(term, word) {
+				continue
+			}
+			if d := editDistance(word, term, limit); d <= limit {
+				merge(term, fuzzyScore-0.1*float64(d-1))
+			}
+		}
+	}
+	return hits
+}
+
+// fuzzyCandidates narrows the vocabulary down to the words that may be
+// within limit edits of word. An edit breaks at most two bigrams, so such a
+// word shares all but 2*limit of the bigrams of word; when word is too short
+// for that to rule anything out, only the length is compared.
+func (idx *index) fuzzyCandidates(word string, limit int) []string {
+	grams := bigrams(word)
+	need := len(grams) - 2*limit
+
+	var candidates []string
+	if need <= 0 {
+		for _, term := range idx.words {
+			if abs(len(term)-len(word)) <= limit {
+				candidates = append(candidates, term)
+			}
+		}
+		return candidates
+	}
+
+	shared := make(map[int]int)
+	for _, gram := range grams {
+		for _, i := range idx.grams[gram] {
+			shared[i]++
+		}
+	}
+	for i, n := range shared {
+		if term := idx.words[i]; n >= need && abs(len(term)-len(word)) <= limit {
+			candidates = append(candidates, term)
+		}
+	}
+	return candidates
+}
+
+// bigrams returns the distinct pairs of adjacent bytes in word.
+func bigrams(word string) []string {
+	var grams []string
+	for i := 0; i+2 <= len(word); i++ {
+		if gram := word[i : i+2]; !slices.Contains(grams, gram) {
+			grams = append(grams, gram)
+		}
+	}
+	return grams
+}
+
+// tokenize splits text into lowercase Latin words.
+func tokenize(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	words := fields[:0]
+	for _, f := range fields {
+		if w := transliterate(f); w != "" {
+			words = append(words, w)
+		}
+	}
+	return words
+}
+
+// fuzzyLimit is how many typos a word of that length may contain.
+func fuzzyLimit(word string) int {
+	switch n := len(word); {
+	case n >= 8:
+		return 2
+	case n >= 4:
+		return 1
+	}
+	return 0
+}
+
+// editDistance is the Levenshtein distance between a and b, or limit+1 as
+// soon as it is known to exceed limit.
+func editDistance(a, b string, limit int) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		best := cur[0]
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			best = min(best, cur[j])
+		}
+		if best > limit {
+			return limit + 1
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,71 @@
+package catalogue
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/lanxre/mc-launcher/backend/parser"
+)
+
+// TestFuzzyCandidates checks that the bigram prefilter keeps every word a
+// full scan of the vocabulary finds within the edit limit.
+func TestFuzzyCandidates(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	word := func() string {
+		b := make([]byte, 3+rng.IntN(9))
+		for i := range b {
+			b[i] = "abcde"[rng.IntN(5)]
+		}
+		return string(b)
+	}
+
+	mods := make([]parser.MinecraftMod, 300)
+	for i := range mods {
+		mods[i].Name = word() + " " + word()
+	}
+	idx := newIndex(mods)
+
+	for range 500 {
+		query := word()
+		limit := fuzzyLimit(query)
+		if limit == 0 {
+			continue
+		}
+		candidates := idx.fuzzyCandidates(query, limit)
+		for _, term := range idx.words {
+			if editDistance(query, term, limit) <= limit && !slices.Contains(candidates, term) {
+				t.Fatalf("fuzzyCandidates(%q) misses %q", query, term)
+			}
+		}
+	}
+}
+
+func TestIndexSearch(t *testing.T) {
+	idx := newIndex([]parser.MinecraftMod{
+		{Name: "Just Enough Items", Loaders: []string{"Forge"}},
+		{Name: "JourneyMap", Description: "Карта мира"},
+		{Name: "Applied Energistics 2"},
+	})
+	tests := map[string][]int{
+		"just":       {0},
+		"enogh":      {0},
+		"journeymap": {1},
+		"jouneymap":  {1},
+		"карта":      {1},
+		"karta":      {1},
+		"energistic": {2},
+		"forge":      {0},
+		"minecraft":  nil,
+	}
+	for query, want := range tests {
+		var got []int
+		for id := range idx.search(query) {
+			got = append(got, id)
+		}
+		slices.Sort(got)
+		if !slices.Equal(got, want) {
+			t.Errorf("search(%q) = %v, want %v", query, got, want)
+		}
+	}
+}
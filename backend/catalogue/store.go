@@ -0,0 +1,78 @@
+package catalogue
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/lanxre/mc-launcher/backend/appdata"
+	"github.com/lanxre/mc-launcher/backend/parser"
+	"gopkg.in/yaml.v3"
+)
+
+// snapshot is the crawled catalogue of one source as stored on disk.
+// NextPage is the list page the crawler resumes from, 0 once the whole
+// list has been walked.
+type snapshot struct {
+	Source    string                `yaml:"source"`
+	NextPage  int                   `yaml:"next_page"`
+	UpdatedAt time.Time             `yaml:"updated_at"`
+	Mods      []parser.MinecraftMod `yaml:"mods"`
+}
+
+func snapshotPath(sourceID string) (string, error) {
+	dir, err := appdata.Dir("catalogue")
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, sourceID+".yaml"), nil
+}
+
+// loadSnapshot reads the stored catalogue of sourceID. A missing file is an
+// empty catalogue.
+func loadSnapshot(sourceID string) (snapshot, error) {
+	snap := snapshot{Source: sourceID}
+
+	path, err := snapshotPath(sourceID)
+	if err != nil {
+		return snap, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return snap, nil
+		}
+		return snap, fmt.Errorf("failed to read catalogue: %w", err)
+	}
+
+	if err := yaml.Unmarshal(data, &snap); err != nil {
+		return snapshot{Source: sourceID}, fmt.Errorf("invalid catalogue file %s: %w", path, err)
+	}
+	return snap, nil
+}
+
+// saveSnapshot writes snap through a temporary file, so an interrupted
+// write never leaves a truncated catalogue behind.
+func saveSnapshot(snap snapshot) error {
+	path, err := snapshotPath(snap.Source)
+	if err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(snap)
+	if err != nil {
+		return fmt.Errorf("failed to marshal catalogue: %w", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write catalogue: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write catalogue: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,427 @@
+package filetools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/lanxre/mc-launcher/backend/appdata"
+	"github.com/lanxre/mc-launcher/backend/nettools"
+	"github.com/lanxre/mc-launcher/backend/operations"
+	"github.com/lanxre/mc-launcher/backend/parser"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	downloadsFile = "downloads.yaml"
+
+	// EventDownloadUpdate carries a Download every time its state or
+	// progress changes.
+	EventDownloadUpdate = "download:update"
+
+	DefaultConcurrency = 3
+)
+
+var ErrDownloadNotFound = errors.New("not_found: no such download")
+
+type DownloadState string
+
+const (
+	StateQueued    DownloadState = "queued"
+	StateRunning   DownloadState = "running"
+	StatePaused    DownloadState = "paused"
+	StateDone      DownloadState = "done"
+	StateFailed    DownloadState = "failed"
+	StateCancelled DownloadState = "cancelled"
+	// StateWaiting is a download stopped by a network outage. It keeps its
+	// part file and is queued again once the network is back.
+	StateWaiting DownloadState = "waiting"
+)
+
+// Download is one entry of the download queue. Filename is the intended
+// name until the download is done, then the name it was installed under
+// at Path. Speed is in bytes per second; Total and ETASeconds are 0 while
+// unknown.
+type Download struct {
+	ID          string            `yaml:"id"`
+	URL         string            `yaml:"url"`
+	Category    parser.Category   `yaml:"category"`
+	Filename    string            `yaml:"filename"`
+	Hashes      map[string]string `yaml:"hashes,omitempty"`
+	Mod         string            `yaml:"mod,omitempty"`
+	OnCollision CollisionPolicy   `yaml:"on_collision,omitempty"`
+	Path        string            `yaml:"path"`
+	State       DownloadState     `yaml:"state"`
+	Received    int64             `yaml:"received"`
+	Total       int64             `yaml:"total"`
+	Speed       float64           `yaml:"speed"`
+	ETASeconds  int64             `yaml:"eta_seconds"`
+	Error       string            `yaml:"error"`
+	AddedAt     time.Time         `yaml:"added_at"`
+}
+
+func (d Download) destination() destination {
+	return destination{
+		category:    d.Category,
+		filename:    d.Filename,
+		hashes:      d.Hashes,
+		mod:         d.Mod,
+		onCollision: d.OnCollision,
+	}
+}
+
+func (d Download) finished() bool {
+	return d.State == StateDone || d.State == StateFailed || d.State == StateCancelled
+}
+
+// DownloadOptions configures the download manager of a FileService. Emit
+// pushes events to the frontend and may be nil.
+type DownloadOptions struct {
+	Concurrency int
+	Emit        func(name string, data ...interface{})
+}
+
+// downloadManager runs the queued downloads, at most concurrency at a
+// time, and keeps the queue on disk so it survives restarts.
+type downloadManager struct {
+	ops  *operations.Registry
+	emit func(name string, data ...interface{})
+
+	mu          sync.Mutex
+	items       []*Download
+	concurrency int
+	running     int
+	stopAs      map[string]DownloadState // state to enter when a running download is cancelled
+	seq         int
+}
+
+func newDownloadManager(ops *operations.Registry, opts DownloadOptions) *downloadManager {
+	m := &downloadManager{
+		ops:         ops,
+		emit:        opts.Emit,
+		concurrency: opts.Concurrency,
+		stopAs:      make(map[string]DownloadState),
+	}
+	if m.concurrency <= 0 {
+		m.concurrency = DefaultConcurrency
+	}
+	if m.emit == nil {
+		m.emit = func(string, ...interface{}) {}
+	}
+
+	items, err := loadDownloads()
+	if err != nil {
+		log.Printf("⚠️ Failed to restore downloads: %v", err)
+	}
+	for _, d := range items {
+		if d.State == StateRunning || d.State == StateWaiting {
+			d.State = StateQueued
+		}
+		m.items = append(m.items, d)
+	}
+
+	m.mu.Lock()
+	m.schedule()
+	m.mu.Unlock()
+	return m
+}
+
+func (m *downloadManager) add(url string, dest destination) Download {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.seq++
+	d := &Download{
+		ID:          fmt.Sprintf("dl-%d-%d", time.Now().UnixNano(), m.seq),
+		URL:         url,
+		Category:    dest.category,
+		Filename:    dest.filename,
+		Hashes:      dest.hashes,
+		Mod:         dest.mod,
+		OnCollision: dest.onCollision,
+		State:       StateQueued,
+		AddedAt:     time.Now(),
+	}
+	m.items = append(m.items, d)
+	m.changed(d)
+	m.schedule()
+	return *d
+}
+
+func (m *downloadManager) list() []Download {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	downloads := make([]Download, 0, len(m.items))
+	for _, d := range m.items {
+		downloads = append(downloads, *d)
+	}
+	return downloads
+}
+
+// pause stops a queued or running download so it is not started again
+// until it is resumed.
+func (m *downloadManager) pause(id string) error {
+	return m.update(id, func(d *Download) {
+		switch d.State {
+		case StateQueued, StateWaiting:
+			d.State = StatePaused
+		case StateRunning:
+			m.stop(d, StatePaused)
+		}
+	})
+}
+
+func (m *downloadManager) resume(id string) error {
+	return m.update(id, func(d *Download) {
+		if d.State == StatePaused {
+			d.State = StateQueued
+		}
+	})
+}
+
+func (m *downloadManager) cancel(id string) error {
+	return m.update(id, func(d *Download) {
+		switch d.State {
+		case StateQueued, StatePaused, StateWaiting:
+			d.State = StateCancelled
+			d.destination().removePart()
+		case StateRunning:
+			m.stop(d, StateCancelled)
+		}
+	})
+}
+
+// retry queues a failed, cancelled or waiting download again. A failed or
+// waiting download resumes from its part file.
+func (m *downloadManager) retry(id string) error {
+	return m.update(id, func(d *Download) {
+		if d.State == StateFailed || d.State == StateCancelled || d.State == StateWaiting {
+			d.State = StateQueued
+			d.Received, d.Total, d.Speed, d.ETASeconds, d.Error = 0, 0, 0, 0, ""
+		}
+	})
+}
+
+// retryWith queues a failed download again with another collision policy.
+func (m *downloadManager) retryWith(id string, policy CollisionPolicy) error {
+	return m.update(id, func(d *Download) {
+		if d.State == StateFailed {
+			d.OnCollision = policy
+			d.State = StateQueued
+			d.Received, d.Total, d.Speed, d.ETASeconds, d.Error = 0, 0, 0, 0, ""
+		}
+	})
+}
+
+// resumeWaiting queues every download that waits for the network.
+func (m *downloadManager) resumeWaiting() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, d := range m.items {
+		if d.State == StateWaiting {
+			d.State = StateQueued
+			d.Error = ""
+			m.changed(d)
+		}
+	}
+	m.schedule()
+}
+
+// clearFinished drops done, failed and cancelled downloads from the list.
+func (m *downloadManager) clearFinished() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.items = slices.DeleteFunc(m.items, func(d *Download) bool { return d.finished() })
+	m.save()
+}
+
+func (m *downloadManager) setConcurrency(n int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if n <= 0 {
+		n = DefaultConcurrency
+	}
+	m.concurrency = n
+	m.schedule()
+}
+
+func (m *downloadManager) update(id string, fn func(d *Download)) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	i := slices.IndexFunc(m.items, func(d *Download) bool { return d.ID == id })
+	if i < 0 {
+		return fmt.Errorf("%w: %s", ErrDownloadNotFound, id)
+	}
+	d := m.items[i]
+	before := d.State
+	fn(d)
+	if d.State != before {
+		m.changed(d)
+	}
+	m.schedule()
+	return nil
+}
+
+// stop cancels a running download; run moves it to state once it returns.
+func (m *downloadManager) stop(d *Download, state DownloadState) {
+	m.stopAs[d.ID] = state
+	m.ops.Cancel(downloadOpID(d.ID))
+}
+
+// schedule starts queued downloads while there are free slots. The caller
+// must hold m.mu.
+func (m *downloadManager) schedule() {
+	for _, d := range m.items {
+		if m.running >= m.concurrency {
+			return
+		}
+		if d.State != StateQueued {
+			continue
+		}
+		d.State = StateRunning
+		d.Error = ""
+		m.running++
+		m.changed(d)
+		go m.run(d.ID, d.URL, d.destination())
+	}
+}
+
+func (m *downloadManager) run(id, url string, dest destination) {
+	p := &progress{onUpdate: func(received, total int64, speed float64) {
+		m.progress(id, received, total, speed)
+	}}
+
+	installed, err := operations.Run(m.ops, downloadOpID(id), func(ctx context.Context) (string, error) {
+		return downloadFile(withProgress(ctx, p), newHTTPClient(), url, dest)
+	})
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.running--
+
+	i := slices.IndexFunc(m.items, func(d *Download) bool { return d.ID == id })
+	state, stopped := m.stopAs[id]
+	delete(m.stopAs, id)
+	if i < 0 {
+		m.schedule()
+		return
+	}
+
+	d := m.items[i]
+	d.Speed, d.ETASeconds = 0, 0
+	switch {
+	case err == nil:
+		d.State = StateDone
+		d.Received, d.Total = p.counts()
+		d.Path = installed
+		d.Filename = filepath.Base(installed)
+	case stopped:
+		d.State = state
+		if state == StateCancelled {
+			dest.removePart()
+		}
+	case errors.Is(err, operations.ErrCancelled):
+		// Cancelled from outside, e.g. by App.CancelOperation or shutdown.
+		d.State = StatePaused
+	case nettools.IsUnreachable(err):
+		// Not an error of the download: the state alone says it waits.
+		d.State = StateWaiting
+		d.Error = ""
+	default:
+		d.State = StateFailed
+		d.Error = err.Error()
+	}
+	m.changed(d)
+	m.schedule()
+}
+
+func (m *downloadManager) progress(id string, received, total int64, speed float64) {
+	m.mu.Lock()
+	i := slices.IndexFunc(m.items, func(d *Download) bool { return d.ID == id })
+	if i < 0 || m.items[i].State != StateRunning {
+		m.mu.Unlock()
+		return
+	}
+
+	d := m.items[i]
+	d.Received, d.Total, d.Speed = received, total, speed
+	d.ETASeconds = 0
+	if total > received && speed > 0 {
+		d.ETASeconds = int64(float64(total-received) / speed)
+	}
+	snapshot := *d
+	m.mu.Unlock()
+
+	m.emit(EventDownloadUpdate, snapshot)
+}
+
+// changed persists the queue and reports the new state of d. The caller
+// must hold m.mu.
+func (m *downloadManager) changed(d *Download) {
+	m.save()
+	m.emit(EventDownloadUpdate, *d)
+}
+
+func (m *downloadManager) save() {
+	if err := saveDownloads(m.items); err != nil {
+		log.Printf("⚠️ Failed to save downloads: %v", err)
+	}
+}
+
+func downloadOpID(id string) string {
+	return "download-" + id
+}
+
+func loadDownloads() ([]*Download, error) {
+	path, err := downloadsPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read downloads: %w", err)
+	}
+
+	var downloads []*Download
+	if err := yaml.Unmarshal(data, &downloads); err != nil {
+		return nil, fmt.Errorf("invalid downloads file %s: %w", path, err)
+	}
+	return downloads, nil
+}
+
+func saveDownloads(downloads []*Download) error {
+	path, err := downloadsPath()
+	if err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(downloads)
+	if err != nil {
+		return fmt.Errorf("failed to marshal downloads: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write downloads: %w", err)
+	}
+	return nil
+}
+
+func downloadsPath() (string, error) {
+	dir, err := appdata.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, downloadsFile), nil
+}
@@ -1,74 +1,195 @@
 package filetools
 
 import (
+	"context"
 	"fmt"
 	"io"
+	"log"
+	"mime"
 	"net/http"
-	"net/http/cookiejar"
 	"os"
-	"path"
 	"path/filepath"
 	"regexp"
 	"strings"
 	"time"
 
 	"github.com/lanxre/mc-launcher/backend/functools"
+	"github.com/lanxre/mc-launcher/backend/nettools"
+	"github.com/lanxre/mc-launcher/backend/operations"
 	"github.com/lanxre/mc-launcher/backend/parser"
+	"github.com/lanxre/mc-launcher/backend/session"
 )
 
-type FileService struct{}
+const maxRedirects = 5
 
-func NewFileService() *FileService {
-	return &FileService{}
+type FileService struct {
+	ops       *operations.Registry
+	pending   *pendingQueue
+	downloads *downloadManager
 }
 
-func (fs *FileService) DownloadFileToMinecraftMods(url, filename string) error {
-	client := newHTTPClient()
-	return downloadFile(client, url, filename)
+func NewFileService(ops *operations.Registry, opts DownloadOptions) *FileService {
+	return &FileService{ops: ops, pending: &pendingQueue{}, downloads: newDownloadManager(ops, opts)}
 }
 
-func (fs *FileService) DownloadsMods(modNames []string, details []parser.DownloadInfo) {
-	for i, detail := range details {
-		name := strings.ToLower(strings.ReplaceAll(modNames[i], " ", "_"))
-		version := strings.Join(strings.Split(detail.Version, ", "), "_")
-		filename := fmt.Sprintf("%s_%s.jar", name, version)
-		if err := fs.DownloadFileToMinecraftMods(detail.URL, filename); err != nil {
-			fmt.Printf("Failed to download %s: %v\n", filename, err)
+func (fs *FileService) DownloadFileToMinecraftMods(opID, url, filename string) error {
+	return operations.Do(fs.ops, opID, func(ctx context.Context) error {
+		return fs.install(ctx, newHTTPClient(), url, modsDestination(filename))
+	})
+}
+
+// installCategory parses category and rejects the ones that cannot be
+// installed directly.
+func installCategory(category string) (parser.Category, error) {
+	c, err := parser.ParseCategory(category)
+	if err != nil {
+		return "", err
+	}
+	if !c.Installable() {
+		return "", fmt.Errorf("%s: %w", c, parser.ErrNotInstallable)
+	}
+	return c, nil
+}
+
+// DownloadToCategory installs the file at url into the directory of the
+// given catalogue category, unpacking map archives into saves. Modpacks
+// are rejected with parser.ErrNotInstallable.
+func (fs *FileService) DownloadToCategory(opID, category, url, filename string) error {
+	c, err := installCategory(category)
+	if err != nil {
+		return err
+	}
+	return operations.Do(fs.ops, opID, func(ctx context.Context) error {
+		return fs.install(ctx, newHTTPClient(), url, destination{category: c, filename: filename})
+	})
+}
+
+// ModDownload is a file of a mod to install. OnCollision defaults to
+// CollisionReport; see ResolveDownloadCollision.
+type ModDownload struct {
+	Name        string          `yaml:"name"`
+	File        parser.ModFile  `yaml:"file"`
+	OnCollision CollisionPolicy `yaml:"on_collision"`
+}
+
+// fallbackName is the file name used when neither the source nor the
+// server names the file.
+func (m ModDownload) fallbackName() string {
+	if m.File.FileName != "" {
+		return m.File.FileName
+	}
+	name := functools.ConverModName(m.Name)
+	version := strings.ReplaceAll(strings.Join(m.File.Versions, "_"), "–", "-")
+	return fmt.Sprintf("%s_%s.jar", name, version)
+}
+
+// DownloadsMods queues the given files for the download manager and
+// returns the queued downloads. Files are named as the server names them.
+// Progress is reported through EventDownloadUpdate events.
+func (fs *FileService) DownloadsMods(mods []ModDownload) ([]Download, error) {
+	for _, m := range mods {
+		if m.File.ManualURL != "" {
+			return nil, fmt.Errorf("mod %q must be downloaded from %s: %w", m.Name, m.File.ManualURL, parser.ErrManualDownload)
 		}
+		if m.File.URL == "" {
+			return nil, fmt.Errorf("mod %q has no download URL", m.Name)
+		}
+	}
+
+	queued := make([]Download, 0, len(mods))
+	for _, m := range mods {
+		dest := modsDestination(m.fallbackName())
+		dest.hashes = m.File.Hashes
+		dest.mod = m.Name
+		dest.onCollision = m.OnCollision
+		queued = append(queued, fs.downloads.add(m.File.URL, dest))
+	}
+	return queued, nil
+}
+
+// QueueDownload adds the file at url to the download queue, to be
+// installed into the directory of the given category.
+func (fs *FileService) QueueDownload(category, url, filename string) (Download, error) {
+	c, err := installCategory(category)
+	if err != nil {
+		return Download{}, err
+	}
+	return fs.downloads.add(url, destination{category: c, filename: filename}), nil
+}
+
+func (fs *FileService) GetDownloads() []Download {
+	return fs.downloads.list()
+}
+
+func (fs *FileService) PauseDownload(id string) error {
+	return fs.downloads.pause(id)
+}
+
+func (fs *FileService) ResumeDownload(id string) error {
+	return fs.downloads.resume(id)
+}
+
+func (fs *FileService) CancelDownload(id string) error {
+	return fs.downloads.cancel(id)
+}
+
+func (fs *FileService) RetryDownload(id string) error {
+	return fs.downloads.retry(id)
+}
+
+// ResolveDownloadCollision retries a download that failed with
+// ErrNameCollision, replacing the existing file or keeping both as policy
+// says.
+func (fs *FileService) ResolveDownloadCollision(id, policy string) error {
+	p := CollisionPolicy(policy)
+	if p != CollisionReplace && p != CollisionKeepBoth {
+		return fmt.Errorf("unknown collision policy %q", policy)
 	}
+	return fs.downloads.retryWith(id, p)
+}
+
+// ClearFinishedDownloads removes done, failed and cancelled downloads from
+// the queue.
+func (fs *FileService) ClearFinishedDownloads() {
+	fs.downloads.clearFinished()
+}
+
+// SetDownloadConcurrency sets how many downloads run at once.
+func (fs *FileService) SetDownloadConcurrency(n int) {
+	fs.downloads.setConcurrency(n)
 }
 
 func newHTTPClient() *http.Client {
-	jar, _ := cookiejar.New(nil)
 	return &http.Client{
-		Timeout: 30 * time.Second,
-		Jar:     jar,
+		Timeout:   30 * time.Second,
+		Jar:       nettools.Jar(),
+		Transport: nettools.Transport(),
 		CheckRedirect: func(*http.Request, []*http.Request) error {
 			return http.ErrUseLastResponse
 		},
 	}
 }
 
-func downloadFile(client *http.Client, url, filename string) error {
-	fmt.Printf("Attempting to download from: %s\n", url)
-	resp, err := get(client, url)
+func downloadFile(ctx context.Context, client *http.Client, url string, dest destination) (string, error) {
+	log.Printf("⬇️ Downloading %s", url)
+	resp, err := get(ctx, client, url)
 	if err != nil {
-		return fmt.Errorf("request failed: %w", err)
+		return "", fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	switch resp.StatusCode {
 	case http.StatusFound, http.StatusMovedPermanently:
-		return handleRedirect(client, resp, filename)
+		return handleRedirect(ctx, client, resp, dest)
 	case http.StatusOK:
-		return handleOK(client, resp, filename)
+		return handleOK(ctx, client, resp, dest)
 	default:
-		return fmt.Errorf("unexpected status: %d", resp.StatusCode)
+		return "", fmt.Errorf("unexpected status: %d", resp.StatusCode)
 	}
 }
 
-func get(client *http.Client, url string) (*http.Response, error) {
-	req, err := http.NewRequest("GET", url, nil)
+func get(ctx context.Context, client *http.Client, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -76,56 +197,47 @@ func get(client *http.Client, url string) (*http.Response, error) {
 	return client.Do(req)
 }
 
+// setCommonHeaders makes req look like a page visit from the browser of
+// the current header profile.
 func setCommonHeaders(req *http.Request) {
-	headers := map[string]string{
-		"authority":                   "minecraft-inside.ru",
-		"accept":                      "text/html,application/xhtml+xml,application/xml;q=0.9,image/avif,image/webp,image/apng,*/*;q=0.8,application/signed-exchange;v=b3;q=0.7",
-		"accept-encoding":             "gzip, deflate, br, zstd",
-		"accept-language":             "ru,en;q=0.9,ru-RU;q=0.8,en-US;q=0.7",
-		"cookie":                      "mobile=eaa1dd758d8c5d78da751fa16e1c5fa0e5a6fa4af01ccc4c15d2aa7a8fd6211ba%3A2%3A%7Bi%3A0%3Bs%3A6%3A%22mobile%22%3Bi%3A1%3Bs%3A2%3A%22no%22%3B%7D; adtech_uid=c2474981-54ad-49ef-bede-1f1b1a39b5e0%3Aminecraft-inside.ru; top100_id=t1.3039121.1529518743.1761071959165; tmr_lvid=e42a43b57362ce104f124ee371cc8799; tmr_lvidTS=1761071959185; _ym_uid=1761071959560828649; _ym_d=1761071959; domain_sid=SbXGA_cJpSsfai4e5ltdp%3A1762107732675; _ym_isad=1; cf_clearance=FlBtJCOylRx13LpDXd0k3KUatq60KvUmRdeENX3tHPo-1762168464-1.2.1.1-IerBotIhw91Up59Rz2z.SlzGa5TQeGve1gy1yONNMnfn.2UJy1iNkcnjAbXMhnprN0InoLbt.YXKHMTOeIXPIZVZoJ4i1m1zG8.IqbvvfFmH5hWSzqGrsnKrGxIsCq5dFr0tRJeqvPghOkbRzjFIoaaPLzz8A5SRfI.i7Rdr0yP3LUYR.Z9M2STL1ZHZBjrnkfKYjnfi.lz3NG9dR_pyE58PvFZwcYGdiq06Oeq295c; tmr_detect=1%7C1762176413410; t3_sid_3039121=s1.868198389.1762176411167.1762176458086.16.12.2.1..",
-		"priority":                    "u=0, i",
-		"referer":                     "https://minecraft-inside.ru/mods/188701-yori3os-grappling-hooks.html",
-		"sec-ch-ua":                   `"Google Chrome";v="141", "Not?A_Brand";v="8", "Chromium";v="141"`,
-		"sec-ch-ua-arch":              `"x86"`,
-		"sec-ch-ua-bitness":           `"64"`,
-		"sec-ch-ua-full-version":      `"141.0.7390.122"`,
-		"sec-ch-ua-full-version-list": `"Google Chrome";v="141.0.7390.122", "Not?A_Brand";v="8.0.0.0", "Chromium";v="141.0.7390.122"`,
-		"sec-ch-ua-mobile":            "?0",
-		"sec-ch-ua-model":             `""`,
-		"sec-ch-ua-platform":          `"Linux"`,
-		"sec-ch-ua-platform-version":  `"6.17.5"`,
-		"sec-fetch-dest":              "document",
-		"sec-fetch-mode":              "navigate",
-		"sec-fetch-site":              "same-origin",
-		"sec-fetch-user":              "?1",
-		"upgrade-insecure-requests":   "1",
-		"user-agent":                  "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/141.0.0.0 Safari/537.36",
-	}
-	for k, v := range headers {
-		req.Header.Set(k, v)
-	}
-}
-
-func handleRedirect(client *http.Client, resp *http.Response, filename string) error {
-	loc := resp.Header.Get("Location")
-	if loc == "" {
-		return fmt.Errorf("redirect without Location")
-	}
-	return downloadDirect(client, loc, filename)
-}
-
-func handleOK(client *http.Client, resp *http.Response, filename string) error {
+	nettools.ApplyHeaders(req.Header, req.URL)
+}
+
+func handleRedirect(ctx context.Context, client *http.Client, resp *http.Response, dest destination) (string, error) {
+	loc, err := resp.Location()
+	if err != nil {
+		return "", fmt.Errorf("redirect without Location")
+	}
+	return downloadDirect(ctx, client, loc.String(), dest)
+}
+
+func handleOK(ctx context.Context, client *http.Client, resp *http.Response, dest destination) (string, error) {
+	if !isHTML(resp) {
+		if dest.partSize() > 0 && resp.Header.Get("Accept-Ranges") == "bytes" {
+			// Fetch again with a Range header to keep what is on disk.
+			return downloadDirect(ctx, client, resp.Request.URL.String(), dest)
+		}
+		return save(ctx, resp, dest, 0)
+	}
+
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("failed to read body: %w", err)
+		return "", fmt.Errorf("failed to read body: %w", err)
 	}
 	if isCloudflare(string(body)) {
-		return fmt.Errorf("cloudflare protection detected")
+		return "", session.ErrChallengeRequired
 	}
 	if url := extractURL(string(body)); url != "" {
-		return downloadDirect(client, url, filename)
+		return downloadDirect(ctx, client, url, dest)
 	}
-	return fmt.Errorf("no download link found")
+	return "", fmt.Errorf("no download link found")
+}
+
+// isHTML reports whether resp is a page to search for a download link
+// rather than the file itself, as API sources link straight to the jar.
+func isHTML(resp *http.Response) bool {
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	return mediaType == "text/html" || mediaType == "application/xhtml+xml"
 }
 
 func isCloudflare(body string) bool {
@@ -155,43 +267,61 @@ func extractURL(body string) string {
 	return ""
 }
 
-func downloadDirect(client *http.Client, url, filename string) error {
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return err
-	}
-	req.Header.Set("User-Agent", "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36")
-	req.Header.Set("Accept", "*/*")
-
-	resp, err := client.Do(req)
-	if err != nil {
-		return fmt.Errorf("download request failed: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("download failed: %d", resp.StatusCode)
-	}
-
-	finalPath, err := functools.GetMinecraftModsPath()
-	finalPath = path.Join(finalPath, filename)
-	if err != nil {
-		return fmt.Errorf("get mod path failed: %w", err)
-	}
+// downloadDirect fetches the file itself, resuming from the part file an
+// interrupted attempt left behind.
+func downloadDirect(ctx context.Context, client *http.Client, url string, dest destination) (string, error) {
+	for redirects := 0; ; redirects++ {
+		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+		if err != nil {
+			return "", err
+		}
+		nettools.ApplyHeaders(req.Header, req.URL)
+		req.Header.Set("Accept", "*/*")
+		offset := dest.partSize()
+		if offset > 0 {
+			req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+		}
 
-	if err := os.MkdirAll(filepath.Dir(finalPath), 0755); err != nil {
-		return fmt.Errorf("create directory failed: %w", err)
-	}
+		resp, err := client.Do(req)
+		if err != nil {
+			return "", fmt.Errorf("download request failed: %w", err)
+		}
 
-	file, err := os.Create(finalPath)
-	if err != nil {
-		return fmt.Errorf("create file failed: %w", err)
-	}
-	defer file.Close()
-	if _, err := io.Copy(file, resp.Body); err != nil {
-		return fmt.Errorf("copy file failed: %w", err)
+		switch resp.StatusCode {
+		case http.StatusOK:
+			defer resp.Body.Close()
+			return save(ctx, resp, dest, 0)
+		case http.StatusPartialContent:
+			defer resp.Body.Close()
+			var start int64
+			if _, err := fmt.Sscanf(resp.Header.Get("Content-Range"), "bytes %d-", &start); err != nil || start != offset {
+				dest.removePart()
+				return "", fmt.Errorf("server resumed at the wrong offset, try again")
+			}
+			return save(ctx, resp, dest, offset)
+		case http.StatusRequestedRangeNotSatisfiable:
+			// The part file does not match the file any more; start over.
+			resp.Body.Close()
+			dest.removePart()
+			if offset == 0 || redirects >= maxRedirects {
+				return "", fmt.Errorf("download failed: %d", resp.StatusCode)
+			}
+		case http.StatusMovedPermanently, http.StatusFound, http.StatusSeeOther,
+			http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+			loc, err := resp.Location()
+			resp.Body.Close()
+			if err != nil {
+				return "", fmt.Errorf("redirect without Location")
+			}
+			if redirects >= maxRedirects {
+				return "", fmt.Errorf("too many redirects downloading %s", dest.filename)
+			}
+			url = loc.String()
+		default:
+			resp.Body.Close()
+			return "", fmt.Errorf("download failed: %d", resp.StatusCode)
+		}
 	}
-	return nil
 }
 
 func (s *FileService) RemoveAllMods() error {
@@ -0,0 +1,281 @@
+package filetools
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/lanxre/mc-launcher/backend/functools"
+	"github.com/lanxre/mc-launcher/backend/parser"
+)
+
+// destination is where a download ends up: a file in the install
+// directory of its category. filename is only used when the server does
+// not name the file. hashes are the checksums the source published for
+// the file, by algorithm, and mod the name of the mod it belongs to.
+type destination struct {
+	category    parser.Category
+	filename    string
+	hashes      map[string]string
+	mod         string
+	onCollision CollisionPolicy
+}
+
+func modsDestination(filename string) destination {
+	return destination{category: parser.CategoryMods, filename: filename}
+}
+
+// collisionPolicy returns the policy for an existing file of the same
+// name. Mods report the collision by default: replacing could overwrite a
+// different mod, and keeping both could load one mod twice.
+func (d destination) collisionPolicy() CollisionPolicy {
+	switch {
+	case d.onCollision != "":
+		return d.onCollision
+	case d.category == parser.CategoryMods:
+		return CollisionReport
+	default:
+		return CollisionKeepBoth
+	}
+}
+
+func (d destination) dir() (string, error) {
+	return functools.GetMinecraftCategoryPath(d.category)
+}
+
+// path returns where the file goes if the server does not name it.
+func (d destination) path() (string, error) {
+	dir, err := d.dir()
+	if err != nil {
+		return "", fmt.Errorf("get install path failed: %w", err)
+	}
+	return filepath.Join(dir, filepath.Base(d.filename)), nil
+}
+
+// partPath returns the file the download is written to until it is
+// complete.
+func (d destination) partPath() (string, error) {
+	path, err := d.path()
+	if err != nil {
+		return "", err
+	}
+	return path + functools.PartFileExt, nil
+}
+
+// partSize returns how many bytes of an earlier attempt are on disk.
+func (d destination) partSize() int64 {
+	part, err := d.partPath()
+	if err != nil {
+		return 0
+	}
+	info, err := os.Stat(part)
+	if err != nil {
+		return 0
+	}
+	return info.Size()
+}
+
+// removePart deletes what an abandoned download left behind.
+func (d destination) removePart() {
+	if part, err := d.partPath(); err == nil {
+		os.Remove(part)
+	}
+}
+
+// save writes the body of resp into the part file of dest, appending to
+// it when resp answers a Range request from offset. Once the expected
+// length has arrived the part file is renamed into place, or unpacked for
+// categories that extract archives. An interrupted copy keeps the part
+// file so the next attempt can resume it. When the server sends no length
+// and the source no hash, the file must open as an archive, as every file
+// the launcher installs is a jar or zip.
+func save(ctx context.Context, resp *http.Response, dest destination, offset int64) (string, error) {
+	part, err := dest.partPath()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(part), 0755); err != nil {
+		return "", fmt.Errorf("create directory failed: %w", err)
+	}
+
+	flags := os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	if offset == 0 {
+		flags |= os.O_TRUNC
+	}
+	file, err := os.OpenFile(part, flags, 0644)
+	if err != nil {
+		return "", fmt.Errorf("create file failed: %w", err)
+	}
+
+	total := expectedLength(resp, offset)
+	written, err := io.Copy(file, trackBody(ctx, resp, offset, total))
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", fmt.Errorf("copy file failed: %w", err)
+	}
+
+	if size := offset + written; total > 0 && size != total {
+		if size > total {
+			os.Remove(part)
+		}
+		return "", fmt.Errorf("incomplete download: got %d of %d bytes", size, total)
+	}
+	if total < 0 && len(dest.hashes) == 0 && !isValidJar(part) {
+		os.Remove(part)
+		return "", fmt.Errorf("incomplete download: %s is not a complete archive", dest.filename)
+	}
+	return finish(part, dest, resp)
+}
+
+// expectedLength returns the full size of the file resp delivers, or -1
+// when the server does not say.
+func expectedLength(resp *http.Response, offset int64) int64 {
+	if resp.StatusCode == http.StatusPartialContent {
+		var start, end, total int64
+		if _, err := fmt.Sscanf(resp.Header.Get("Content-Range"), "bytes %d-%d/%d", &start, &end, &total); err == nil {
+			return total
+		}
+	}
+	if resp.ContentLength < 0 {
+		return -1
+	}
+	return offset + resp.ContentLength
+}
+
+// finish checks a complete part file against the source's hashes and
+// moves it into place under the name the server gave it, or unpacks it.
+// It returns the installed path.
+func finish(part string, dest destination, resp *http.Response) (string, error) {
+	sums, size, err := fileHashes(part, dest.hashes)
+	if err != nil {
+		return "", err
+	}
+	verified, err := checkHashes(sums, dest.hashes)
+	if err != nil {
+		os.Remove(part)
+		return "", err
+	}
+
+	dir := filepath.Dir(part)
+	name := serverFilename(resp, dest.filename)
+	if dest.category.Extract() && strings.EqualFold(filepath.Ext(name), ".zip") {
+		defer os.Remove(part)
+		return saveExtracted(part, dir, name)
+	}
+
+	path, reserved, err := resolveCollision(filepath.Join(dir, name), sums["sha1"], dest.collisionPolicy())
+	if err != nil {
+		return "", err
+	}
+	if err := os.Rename(part, path); err != nil {
+		if reserved {
+			os.Remove(path)
+		}
+		return "", fmt.Errorf("move file into place failed: %w", err)
+	}
+
+	record := IntegrityRecord{
+		SHA1:        sums["sha1"],
+		SHA512:      sums["sha512"],
+		Size:        size,
+		URL:         resp.Request.URL.String(),
+		Verified:    verified,
+		InstalledAt: time.Now(),
+	}
+	if err := recordIntegrity(path, record); err != nil {
+		log.Printf("⚠️ Failed to record hashes of %s: %v", path, err)
+	}
+	if dest.mod != "" {
+		if err := functools.RecordInstalledMod(dest.mod, filepath.Base(path)); err != nil {
+			log.Printf("⚠️ Failed to record installed mod %s: %v", dest.mod, err)
+		}
+	}
+	return path, nil
+}
+
+// saveExtracted unpacks the zip archive at archivePath into
+// dir/<archive name>, flattening a single top-level folder so worlds land
+// directly in saves. It returns the directory it unpacked into.
+func saveExtracted(archivePath, dir, filename string) (string, error) {
+	reader, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return "", fmt.Errorf("open archive failed: %w", err)
+	}
+	defer reader.Close()
+	archive := &reader.Reader
+
+	target := filepath.Join(dir, strings.TrimSuffix(filepath.Base(filename), filepath.Ext(filename)))
+	prefix := commonRoot(archive.File)
+
+	for _, f := range archive.File {
+		name := strings.TrimPrefix(f.Name, prefix)
+		if name == "" {
+			continue
+		}
+		if err := extractFile(f, target, name); err != nil {
+			return "", err
+		}
+	}
+	return target, nil
+}
+
+func extractFile(f *zip.File, target, name string) error {
+	path := filepath.Join(target, filepath.FromSlash(name))
+	if !strings.HasPrefix(path, filepath.Clean(target)+string(os.PathSeparator)) {
+		return fmt.Errorf("archive entry %q escapes target directory", f.Name)
+	}
+
+	if f.FileInfo().IsDir() {
+		return os.MkdirAll(path, 0755)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("create directory failed: %w", err)
+	}
+
+	src, err := f.Open()
+	if err != nil {
+		return fmt.Errorf("open archive entry failed: %w", err)
+	}
+	defer src.Close()
+
+	dst, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("create file failed: %w", err)
+	}
+	defer dst.Close()
+
+	if _, err := io.Copy(dst, src); err != nil {
+		return fmt.Errorf("extract %s failed: %w", f.Name, err)
+	}
+	return nil
+}
+
+// commonRoot returns the single top-level directory shared by every entry,
+// including its trailing slash, or "" when there is none.
+func commonRoot(files []*zip.File) string {
+	var root string
+	for _, f := range files {
+		first, _, found := strings.Cut(f.Name, "/")
+		if !found {
+			return ""
+		}
+		if root == "" {
+			root = first
+		} else if root != first {
+			return ""
+		}
+	}
+	if root == "" {
+		return ""
+	}
+	return root + "/"
+}
@@ -0,0 +1,228 @@
+package filetools
+
+import (
+	"archive/zip"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lanxre/mc-launcher/backend/appdata"
+	"github.com/lanxre/mc-launcher/backend/functools"
+	"gopkg.in/yaml.v3"
+)
+
+const integrityFile = "integrity.yaml"
+
+var ErrChecksumMismatch = errors.New("checksum_mismatch: downloaded file does not match the hash published by the source")
+
+// IntegrityRecord is what the integrity database knows about an installed
+// file. Verified is set when the hashes matched the ones the source
+// published.
+type IntegrityRecord struct {
+	SHA1        string    `yaml:"sha1"`
+	SHA512      string    `yaml:"sha512"`
+	Size        int64     `yaml:"size"`
+	URL         string    `yaml:"url"`
+	Verified    bool      `yaml:"verified"`
+	InstalledAt time.Time `yaml:"installed_at"`
+}
+
+type IntegrityStatus string
+
+const (
+	IntegrityModified  IntegrityStatus = "modified"
+	IntegrityCorrupted IntegrityStatus = "corrupted"
+	IntegrityUnknown   IntegrityStatus = "unknown"
+)
+
+// ModIntegrity reports a jar in the mods folder that failed verification.
+type ModIntegrity struct {
+	Path   string          `yaml:"path"`
+	Name   string          `yaml:"name"`
+	Status IntegrityStatus `yaml:"status"`
+	SHA1   string          `yaml:"sha1"`
+	SHA512 string          `yaml:"sha512"`
+}
+
+// integrityMu guards the integrity database file.
+var integrityMu sync.Mutex
+
+// fileHashes hashes the file at path with SHA-1 and SHA-512, and with any
+// other algorithm named in expected that is supported.
+func fileHashes(path string, expected map[string]string) (map[string]string, int64, error) {
+	hashers := map[string]hash.Hash{"sha1": sha1.New(), "sha512": sha512.New()}
+	if _, ok := expected["md5"]; ok {
+		hashers["md5"] = md5.New()
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, 0, fmt.Errorf("open file failed: %w", err)
+	}
+	defer file.Close()
+
+	writers := make([]io.Writer, 0, len(hashers))
+	for _, h := range hashers {
+		writers = append(writers, h)
+	}
+	size, err := io.Copy(io.MultiWriter(writers...), file)
+	if err != nil {
+		return nil, 0, fmt.Errorf("hash file failed: %w", err)
+	}
+
+	sums := make(map[string]string, len(hashers))
+	for algo, h := range hashers {
+		sums[algo] = hex.EncodeToString(h.Sum(nil))
+	}
+	return sums, size, nil
+}
+
+// checkHashes compares sums against the hashes the source published. It
+// reports whether any of them could be compared.
+func checkHashes(sums, expected map[string]string) (bool, error) {
+	checked := false
+	for algo, want := range expected {
+		got, ok := sums[strings.ToLower(algo)]
+		if !ok || want == "" {
+			continue
+		}
+		if !strings.EqualFold(got, want) {
+			return false, fmt.Errorf("%w: %s is %s, expected %s", ErrChecksumMismatch, algo, got, want)
+		}
+		checked = true
+	}
+	return checked, nil
+}
+
+// recordIntegrity stores the hashes of the file installed at path.
+func recordIntegrity(path string, record IntegrityRecord) error {
+	integrityMu.Lock()
+	defer integrityMu.Unlock()
+
+	records, err := loadIntegrity()
+	if err != nil {
+		return err
+	}
+	records[path] = record
+	return saveIntegrity(records)
+}
+
+// VerifyMods hashes every jar in the mods folder and reports the ones that
+// are not valid archives, differ from what was installed, or were not
+// installed by the launcher.
+func (fs *FileService) VerifyMods() ([]ModIntegrity, error) {
+	modsPath, err := functools.GetMinecraftModsPath()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(modsPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mods folder: %w", err)
+	}
+
+	integrityMu.Lock()
+	records, err := loadIntegrity()
+	integrityMu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	problems := []ModIntegrity{}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.EqualFold(filepath.Ext(entry.Name()), ".jar") {
+			continue
+		}
+		path := filepath.Join(modsPath, entry.Name())
+		sums, _, err := fileHashes(path, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		report := ModIntegrity{Path: path, Name: entry.Name(), SHA1: sums["sha1"], SHA512: sums["sha512"]}
+		record, known := records[path]
+		switch {
+		case !isValidJar(path):
+			report.Status = IntegrityCorrupted
+		case !known:
+			report.Status = IntegrityUnknown
+		case record.SHA1 != report.SHA1 || record.SHA512 != report.SHA512:
+			report.Status = IntegrityModified
+		default:
+			continue
+		}
+		problems = append(problems, report)
+	}
+	return problems, nil
+}
+
+// isValidJar reports whether path opens as a zip archive, which a
+// truncated jar does not.
+func isValidJar(path string) bool {
+	reader, err := zip.OpenReader(path)
+	if err != nil {
+		return false
+	}
+	reader.Close()
+	return true
+}
+
+func loadIntegrity() (map[string]IntegrityRecord, error) {
+	path, err := integrityPath()
+	if err != nil {
+		return nil, err
+	}
+
+	records := make(map[string]IntegrityRecord)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return records, nil
+		}
+		return nil, fmt.Errorf("failed to read integrity database: %w", err)
+	}
+
+	if err := yaml.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("invalid integrity database %s: %w", path, err)
+	}
+	return records, nil
+}
+
+func saveIntegrity(records map[string]IntegrityRecord) error {
+	path, err := integrityPath()
+	if err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(records)
+	if err != nil {
+		return fmt.Errorf("failed to marshal integrity database: %w", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write integrity database: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write integrity database: %w", err)
+	}
+	return nil
+}
+
+func integrityPath() (string, error) {
+	dir, err := appdata.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, integrityFile), nil
+}
@@ -0,0 +1,104 @@
+package filetools
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// CollisionPolicy says what to do when a download would overwrite a file
+// with different content. CollisionReport fails the install with
+// ErrNameCollision, so the user can retry it with one of the others.
+type CollisionPolicy string
+
+const (
+	CollisionKeepBoth CollisionPolicy = "keep_both"
+	CollisionReplace  CollisionPolicy = "replace"
+	CollisionReport   CollisionPolicy = "report"
+)
+
+var ErrNameCollision = errors.New("name_collision: a different file with this name is already installed")
+
+// maxNameSuffix bounds the " (n)" suffixes tried for CollisionKeepBoth.
+const maxNameSuffix = 1000
+
+var unsafeFilenameChars = strings.NewReplacer(
+	"<", "_", ">", "_", ":", "_", `"`, "_", "|", "_", "?", "_", "*", "_",
+)
+
+// serverFilename returns the name the server gives the file in resp: the
+// Content-Disposition filename, else the last segment of the final URL if
+// it looks like a file. fallback is used when neither does.
+func serverFilename(resp *http.Response, fallback string) string {
+	if _, params, err := mime.ParseMediaType(resp.Header.Get("Content-Disposition")); err == nil {
+		if name := sanitizeFilename(params["filename"]); name != "" {
+			return name
+		}
+	}
+	if resp.Request != nil {
+		if name := sanitizeFilename(path.Base(resp.Request.URL.Path)); filepath.Ext(name) != "" {
+			return name
+		}
+	}
+	return sanitizeFilename(fallback)
+}
+
+// sanitizeFilename strips directories and characters Windows does not
+// allow from name, returning "" if nothing usable is left.
+func sanitizeFilename(name string) string {
+	name = path.Base(strings.ReplaceAll(name, `\`, "/"))
+	name = strings.TrimSpace(unsafeFilenameChars.Replace(name))
+	if name == "." || name == "/" || name == ".." {
+		return ""
+	}
+	return name
+}
+
+// resolveCollision returns where a file with the given SHA-1 should be
+// installed when target may already exist. The same content simply
+// replaces the file; different content is replaced or kept next to the
+// new file or reported depending on policy. A new name is reserved by creating it
+// empty, so concurrent downloads cannot pick the same one; reserved
+// reports whether the caller must remove it if the install fails.
+func resolveCollision(target, sha1 string, policy CollisionPolicy) (path string, reserved bool, err error) {
+	if ok, err := reserveName(target); err != nil || ok {
+		return target, ok, err
+	}
+	if sums, _, err := fileHashes(target, nil); err == nil && sums["sha1"] == sha1 {
+		return target, false, nil
+	}
+	switch policy {
+	case CollisionReplace:
+		return target, false, nil
+	case CollisionReport:
+		return "", false, fmt.Errorf("%w: %s", ErrNameCollision, filepath.Base(target))
+	}
+
+	ext := filepath.Ext(target)
+	base := strings.TrimSuffix(target, ext)
+	for n := 1; n <= maxNameSuffix; n++ {
+		candidate := fmt.Sprintf("%s (%d)%s", base, n, ext)
+		if ok, err := reserveName(candidate); err != nil || ok {
+			return candidate, ok, err
+		}
+	}
+	return "", false, fmt.Errorf("no free file name for %s", filepath.Base(target))
+}
+
+// reserveName creates path if it does not exist yet, reporting whether it
+// did.
+func reserveName(path string) (bool, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if err != nil {
+		if os.IsExist(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to reserve %s: %w", filepath.Base(path), err)
+	}
+	return true, f.Close()
+}
@@ -0,0 +1,190 @@
+package filetools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/lanxre/mc-launcher/backend/appdata"
+	"github.com/lanxre/mc-launcher/backend/nettools"
+	"github.com/lanxre/mc-launcher/backend/operations"
+	"github.com/lanxre/mc-launcher/backend/parser"
+	"gopkg.in/yaml.v3"
+)
+
+const pendingFile = "pending_installs.yaml"
+
+var ErrInstallQueued = errors.New("queued: network is unreachable, the install will run once it is back")
+
+// PendingInstall is a download that failed because the network was down,
+// kept until it can be retried.
+type PendingInstall struct {
+	URL      string            `yaml:"url"`
+	Category parser.Category   `yaml:"category"`
+	Filename string            `yaml:"filename"`
+	Hashes   map[string]string `yaml:"hashes,omitempty"`
+	QueuedAt time.Time         `yaml:"queued_at"`
+}
+
+func (p PendingInstall) destination() destination {
+	return destination{category: p.Category, filename: p.Filename, hashes: p.Hashes}
+}
+
+func (p PendingInstall) key() string {
+	return p.URL + "\n" + string(p.Category) + "\n" + p.Filename
+}
+
+// pendingQueue is the list of pending installs, stored in the data
+// directory so it survives restarts.
+type pendingQueue struct {
+	mu sync.Mutex
+}
+
+func (q *pendingQueue) list() ([]PendingInstall, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return loadPending()
+}
+
+func (q *pendingQueue) add(install PendingInstall) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	installs, err := loadPending()
+	if err != nil {
+		return err
+	}
+	for _, p := range installs {
+		if p.key() == install.key() {
+			return nil
+		}
+	}
+	return savePending(append(installs, install))
+}
+
+// remove drops the installs for which done returns true.
+func (q *pendingQueue) remove(done func(PendingInstall) bool) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	installs, err := loadPending()
+	if err != nil {
+		return err
+	}
+	kept := installs[:0]
+	for _, p := range installs {
+		if !done(p) {
+			kept = append(kept, p)
+		}
+	}
+	return savePending(kept)
+}
+
+func loadPending() ([]PendingInstall, error) {
+	path, err := pendingPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read pending installs: %w", err)
+	}
+
+	var installs []PendingInstall
+	if err := yaml.Unmarshal(data, &installs); err != nil {
+		return nil, fmt.Errorf("invalid pending installs file %s: %w", path, err)
+	}
+	return installs, nil
+}
+
+func savePending(installs []PendingInstall) error {
+	path, err := pendingPath()
+	if err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(installs)
+	if err != nil {
+		return fmt.Errorf("failed to marshal pending installs: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write pending installs: %w", err)
+	}
+	return nil
+}
+
+func pendingPath() (string, error) {
+	dir, err := appdata.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, pendingFile), nil
+}
+
+// install downloads url to dest, queueing it for later when the network
+// is unreachable.
+func (fs *FileService) install(ctx context.Context, client *http.Client, url string, dest destination) error {
+	_, err := downloadFile(ctx, client, url, dest)
+	if err == nil || ctx.Err() != nil || !nettools.IsUnreachable(err) {
+		return err
+	}
+
+	pending := PendingInstall{URL: url, Category: dest.category, Filename: dest.filename, Hashes: dest.hashes, QueuedAt: time.Now()}
+	if err := fs.pending.add(pending); err != nil {
+		return err
+	}
+	return ErrInstallQueued
+}
+
+func (fs *FileService) GetPendingInstalls() ([]PendingInstall, error) {
+	return fs.pending.list()
+}
+
+// RunPendingInstalls retries every pending install and queues again the
+// downloads waiting for the network. Installs that fail for another reason
+// than the network are dropped and reported.
+func (fs *FileService) RunPendingInstalls(opID string) error {
+	fs.downloads.resumeWaiting()
+
+	installs, err := fs.pending.list()
+	if err != nil || len(installs) == 0 {
+		return err
+	}
+
+	return operations.Do(fs.ops, opID, func(ctx context.Context) error {
+		client := newHTTPClient()
+		done := make(map[string]bool)
+		var errs []error
+		for _, p := range installs {
+			if ctx.Err() != nil {
+				break
+			}
+			_, err := downloadFile(ctx, client, p.URL, p.destination())
+			if err != nil && nettools.IsUnreachable(err) {
+				continue
+			}
+			if err != nil {
+				errs = append(errs, fmt.Errorf("failed to install %s: %w", p.Filename, err))
+			}
+			done[p.key()] = true
+		}
+
+		if err := fs.pending.remove(func(p PendingInstall) bool { return done[p.key()] }); err != nil {
+			errs = append(errs, err)
+		}
+		return errors.Join(errs...)
+	})
+}
+
+func (fs *FileService) ClearPendingInstalls() error {
+	return fs.pending.remove(func(PendingInstall) bool { return true })
+}
@@ -0,0 +1,82 @@
+package filetools
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// progressInterval is how often progress is reported while bytes flow.
+const progressInterval = 250 * time.Millisecond
+
+// progress measures the bytes of one download as they are saved.
+type progress struct {
+	onUpdate func(received, total int64, speed float64)
+
+	mu       sync.Mutex
+	received int64
+	total    int64
+	speed    float64
+	lastAt   time.Time
+	lastSize int64
+}
+
+type progressKey struct{}
+
+func withProgress(ctx context.Context, p *progress) context.Context {
+	return context.WithValue(ctx, progressKey{}, p)
+}
+
+// trackBody returns the body of resp, counted by the progress tracker of
+// ctx if there is one. offset is the part already on disk and total the
+// full size, -1 if unknown.
+func trackBody(ctx context.Context, resp *http.Response, offset, total int64) io.Reader {
+	p, ok := ctx.Value(progressKey{}).(*progress)
+	if !ok {
+		return resp.Body
+	}
+	p.start(offset, total)
+	return io.TeeReader(resp.Body, p)
+}
+
+func (p *progress) start(offset, total int64) {
+	p.mu.Lock()
+	p.received, p.total, p.speed = offset, max(total, 0), 0
+	p.lastAt, p.lastSize = time.Now(), offset
+	p.mu.Unlock()
+	p.onUpdate(offset, p.total, 0)
+}
+
+// counts returns the bytes received so far and the expected total.
+func (p *progress) counts() (int64, int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.received, p.total
+}
+
+// Write counts bytes passing through the TeeReader.
+func (p *progress) Write(b []byte) (int, error) {
+	p.mu.Lock()
+	p.received += int64(len(b))
+	now := time.Now()
+	elapsed := now.Sub(p.lastAt)
+	if elapsed < progressInterval {
+		p.mu.Unlock()
+		return len(b), nil
+	}
+
+	current := float64(p.received-p.lastSize) / elapsed.Seconds()
+	if p.speed == 0 {
+		p.speed = current
+	} else {
+		p.speed = 0.7*p.speed + 0.3*current
+	}
+	p.lastAt, p.lastSize = now, p.received
+	received, total, speed := p.received, p.total, p.speed
+	p.mu.Unlock()
+
+	p.onUpdate(received, total, speed)
+	return len(b), nil
+}
@@ -8,6 +8,8 @@ import (
 	"path/filepath"
 	"slices"
 
+	"github.com/lanxre/mc-launcher/backend/mcversion"
+	"github.com/lanxre/mc-launcher/backend/nettools"
 	"github.com/lanxre/mc-launcher/backend/parser"
 )
 
@@ -22,10 +24,8 @@ func (s *FuncService) SortByVersions(mods []parser.MinecraftMod, version string)
 
 	for _, mod := range mods {
 		for _, v := range mod.Versions {
-			if v == version {
-				slices.SortFunc(mod.Versions, func(a, b string) int {
-					return cmp.Compare(a, b)
-				})
+			if mcversion.Match(version, v) {
+				mcversion.Sort(mod.Versions)
 				filteredMods = append(filteredMods, mod)
 				break
 			}
@@ -47,6 +47,42 @@ func (s *FuncService) SortByLoader(mods []parser.MinecraftMod, searchedLoader st
 	return filteredMods
 }
 
+// SortMods orders mods by downloads or by last update, highest first. Other
+// orders keep the list as it is.
+func (s *FuncService) SortMods(mods []parser.MinecraftMod, order parser.SortOrder) []parser.MinecraftMod {
+	switch order {
+	case parser.SortDownloads, parser.SortPopular:
+		slices.SortStableFunc(mods, func(a, b parser.MinecraftMod) int {
+			return cmp.Compare(b.Downloads, a.Downloads)
+		})
+	case parser.SortNewest, parser.SortUpdated:
+		slices.SortStableFunc(mods, func(a, b parser.MinecraftMod) int {
+			return b.Updated.Compare(a.Updated)
+		})
+	}
+	return mods
+}
+
+// SortFiles orders mod files by downloads, publish date or size, highest
+// first.
+func (s *FuncService) SortFiles(files []parser.ModFile, order parser.SortOrder) []parser.ModFile {
+	switch order {
+	case parser.SortDownloads, parser.SortPopular:
+		slices.SortStableFunc(files, func(a, b parser.ModFile) int {
+			return cmp.Compare(b.DownloadCount, a.DownloadCount)
+		})
+	case parser.SortNewest, parser.SortUpdated:
+		slices.SortStableFunc(files, func(a, b parser.ModFile) int {
+			return b.Published.Compare(a.Published)
+		})
+	case parser.SortSize:
+		slices.SortStableFunc(files, func(a, b parser.ModFile) int {
+			return cmp.Compare(b.SizeBytes, a.SizeBytes)
+		})
+	}
+	return files
+}
+
 func (s *FuncService) GetSavedMods() ([]string, error) {
 	finalPath, err := GetMinecraftModsPath()
 
@@ -72,6 +108,32 @@ func (s *FuncService) GetSavedMods() ([]string, error) {
 	return filesNames, nil
 }
 
+func (s *FuncService) GetInstalledContent(category string) ([]string, error) {
+	c, err := parser.ParseCategory(category)
+	if err != nil {
+		return nil, err
+	}
+
+	dir, err := GetMinecraftCategoryPath(c)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []string{}, nil
+		}
+		return nil, err
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	return names, nil
+}
+
 func (s *FuncService) DeleteSavedMod(modName string) {
 	minecraftDir, _ := GetMinecraftModsPath()
 	finalPath := path.Join(minecraftDir, modName)
@@ -105,6 +167,7 @@ func (s *FuncService) GetMinecraftVersions() []string {
 	}
 
 	if len(names) > 0 {
+		mcversion.Sort(names)
 		return names
 	} 
 
@@ -114,4 +177,29 @@ func (s *FuncService) GetMinecraftVersions() []string {
 func (s *FuncService) OpenModsFolder() {
 	modPath, _ := GetMinecraftModsPath()
 	OpenFolder(modPath)
-}
\ No newline at end of file
+}
+
+func (s *FuncService) OpenCategoryFolder(category string) error {
+	c, err := parser.ParseCategory(category)
+	if err != nil {
+		return err
+	}
+
+	dir, err := GetMinecraftCategoryPath(c)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	OpenFolder(dir)
+	return nil
+}
+
+func (s *FuncService) ClearCache() error {
+	return nettools.ClearCache()
+}
+
+func (s *FuncService) GetCacheSize() int64 {
+	return nettools.CacheSize()
+}
@@ -0,0 +1,129 @@
+package functools
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/lanxre/mc-launcher/backend/appdata"
+	"gopkg.in/yaml.v3"
+)
+
+const installedFile = "installed.yaml"
+
+// installedMu guards the installed mods file.
+var installedMu sync.Mutex
+
+// RecordInstalledMod remembers that filename in the mods folder holds the
+// mod called modName, so IsModExist finds mods whose jar is not named
+// after them.
+func RecordInstalledMod(modName, filename string) error {
+	installedMu.Lock()
+	defer installedMu.Unlock()
+
+	installed, err := loadInstalled()
+	if err != nil {
+		return err
+	}
+	installed[filename] = ConverModName(modName)
+	return saveInstalled(installed)
+}
+
+// installedMods returns the recorded mod name of every installed file.
+func installedMods() map[string]string {
+	installedMu.Lock()
+	defer installedMu.Unlock()
+
+	installed, err := loadInstalled()
+	if err != nil {
+		return map[string]string{}
+	}
+	return installed
+}
+
+func loadInstalled() (map[string]string, error) {
+	path, err := installedPath()
+	if err != nil {
+		return nil, err
+	}
+
+	installed := make(map[string]string)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return installed, nil
+		}
+		return nil, fmt.Errorf("failed to read installed mods: %w", err)
+	}
+	if err := yaml.Unmarshal(data, &installed); err != nil {
+		return nil, fmt.Errorf("invalid installed mods file %s: %w", path, err)
+	}
+	return installed, nil
+}
+
+func saveInstalled(installed map[string]string) error {
+	path, err := installedPath()
+	if err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(installed)
+	if err != nil {
+		return fmt.Errorf("failed to marshal installed mods: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write installed mods: %w", err)
+	}
+	return nil
+}
+
+func installedPath() (string, error) {
+	dir, err := appdata.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, installedFile), nil
+}
+
+// DeleteInstalledMod removes every file recorded for the mod called
+// modName from the mods folder, whatever the server named them.
+func (s *FuncService) DeleteInstalledMod(modName string) error {
+	files, err := forgetInstalledMod(modName)
+	if err != nil {
+		return err
+	}
+	modsDir, err := GetMinecraftModsPath()
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if err := os.Remove(filepath.Join(modsDir, file)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove %s: %w", file, err)
+		}
+	}
+	return nil
+}
+
+// forgetInstalledMod drops the files recorded for modName and returns
+// their names.
+func forgetInstalledMod(modName string) ([]string, error) {
+	installedMu.Lock()
+	defer installedMu.Unlock()
+
+	installed, err := loadInstalled()
+	if err != nil {
+		return nil, err
+	}
+	name := ConverModName(modName)
+	var files []string
+	for file, mod := range installed {
+		if mod == name {
+			files = append(files, file)
+			delete(installed, file)
+		}
+	}
+	if len(files) == 0 {
+		return nil, nil
+	}
+	return files, saveInstalled(installed)
+}
@@ -7,8 +7,13 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+
+	"github.com/lanxre/mc-launcher/backend/parser"
 )
 
+// PartFileExt is appended to the name of a file while it is downloaded.
+const PartFileExt = ".part"
+
 func GetMinecraftModsPath() (string, error) {
 	mcPath, err := GetMinecraftPath()
 	if err != nil {
@@ -18,6 +23,17 @@ func GetMinecraftModsPath() (string, error) {
 	return filepath.Join(mcPath, "mods"), nil
 }
 
+// GetMinecraftCategoryPath returns the install directory of category,
+// e.g. .minecraft/resourcepacks.
+func GetMinecraftCategoryPath(category parser.Category) (string, error) {
+	mcPath, err := GetMinecraftPath()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(mcPath, category.InstallDir()), nil
+}
+
 func GetMinecraftModPath(filename string) (string, error) {
 	finalPath, err := GetMinecraftModsPath()
 
@@ -63,9 +79,13 @@ func isExistModInDisk(modName string) bool {
 	}
 
 	supossedName := ConverModName(modName)
+	installed := installedMods()
 
 	for _, entry := range entries {
-		if !entry.IsDir() && strings.HasPrefix(entry.Name(), supossedName) {
+		if entry.IsDir() || strings.HasSuffix(entry.Name(), PartFileExt) {
+			continue
+		}
+		if strings.HasPrefix(entry.Name(), supossedName) || installed[entry.Name()] == supossedName {
 			return !done
 		}
 	}
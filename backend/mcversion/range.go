@@ -0,0 +1,187 @@
+package mcversion
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Bound is one end of a Range. An unset bound leaves that end open.
+type Bound struct {
+	Version   Version
+	Set       bool
+	Inclusive bool
+}
+
+// Range is a span of game versions such as "1.20.1", "1.20.x",
+// "1.19–1.20.4" or ">=1.18".
+type Range struct {
+	Min  Bound
+	Max  Bound
+	text string
+}
+
+// Exact returns the range holding only v.
+func Exact(v Version) Range {
+	b := Bound{Version: v, Set: true, Inclusive: true}
+	return Range{Min: b, Max: b, text: v.String()}
+}
+
+var rangeSeparators = []string{"–", "—", " - ", "..", " to "}
+
+func ParseRange(s string) (Range, error) {
+	text := strings.ToLower(strings.TrimSpace(s))
+	if text == "" {
+		return Range{}, fmt.Errorf("%w: empty range", ErrInvalidVersion)
+	}
+
+	for _, op := range []string{">=", "<=", ">", "<"} {
+		if rest, ok := strings.CutPrefix(text, op); ok {
+			return openRange(op, rest)
+		}
+	}
+	if rest, ok := strings.CutSuffix(text, "+"); ok {
+		return openRange(">=", rest)
+	}
+
+	for _, suffix := range []string{".x", ".*"} {
+		if base, ok := strings.CutSuffix(text, suffix); ok {
+			v, err := Parse(base)
+			if err != nil || v.Kind != Release || v.Patch != 0 {
+				return Range{}, fmt.Errorf("%w: %q", ErrInvalidVersion, s)
+			}
+			return Range{
+				Min:  Bound{Version: v, Set: true, Inclusive: true},
+				Max:  Bound{Version: floor(v.Major, v.Minor+1), Set: true},
+				text: v.String() + ".x",
+			}, nil
+		}
+	}
+
+	for _, sep := range rangeSeparators {
+		if lo, hi, ok := strings.Cut(text, sep); ok {
+			return spanRange(lo, hi)
+		}
+	}
+	// A bare hyphen is also used inside pre-release names such as
+	// "1.20-pre1", so only split where both sides are versions.
+	for i := strings.IndexByte(text, '-'); i != -1; {
+		if r, err := spanRange(text[:i], text[i+1:]); err == nil {
+			return r, nil
+		}
+		next := strings.IndexByte(text[i+1:], '-')
+		if next == -1 {
+			break
+		}
+		i += next + 1
+	}
+
+	v, err := Parse(text)
+	if err != nil {
+		return Range{}, err
+	}
+	return Exact(v), nil
+}
+
+func openRange(op, rest string) (Range, error) {
+	v, err := Parse(rest)
+	if err != nil {
+		return Range{}, err
+	}
+	b := Bound{Version: v, Set: true, Inclusive: strings.HasSuffix(op, "=")}
+	r := Range{text: op + v.String()}
+	if strings.HasPrefix(op, ">") {
+		r.Min = b
+	} else {
+		r.Max = b
+	}
+	return r, nil
+}
+
+func spanRange(lo, hi string) (Range, error) {
+	from, err := Parse(lo)
+	if err != nil {
+		return Range{}, err
+	}
+	to, err := Parse(hi)
+	if err != nil {
+		return Range{}, err
+	}
+	if Compare(from, to) > 0 {
+		from, to = to, from
+	}
+	return Range{
+		Min:  Bound{Version: from, Set: true, Inclusive: true},
+		Max:  Bound{Version: to, Set: true, Inclusive: true},
+		text: from.String() + "–" + to.String(),
+	}, nil
+}
+
+// String returns the canonical form of the range, e.g. "1.19–1.20.4".
+func (r Range) String() string {
+	return r.text
+}
+
+// IsExact reports whether the range holds a single version.
+func (r Range) IsExact() bool {
+	return r.Min.Set && r.Max.Set && r.Min.Inclusive && r.Max.Inclusive &&
+		Compare(r.Min.Version, r.Max.Version) == 0
+}
+
+func (r Range) Contains(v Version) bool {
+	return r.Overlaps(Exact(v))
+}
+
+func (r Range) Overlaps(other Range) bool {
+	return below(r.Min, other.Max) && below(other.Min, r.Max)
+}
+
+// below reports whether the lower bound lo does not lie past the upper
+// bound hi.
+func below(lo, hi Bound) bool {
+	if !lo.Set || !hi.Set {
+		return true
+	}
+	c := Compare(lo.Version, hi.Version)
+	return c < 0 || c == 0 && lo.Inclusive && hi.Inclusive
+}
+
+// Match reports whether two version lists share a version. Entries that do
+// not parse only match an equal entry on the other side.
+func Match(a, b string) bool {
+	for _, x := range splitList(a) {
+		for _, y := range splitList(b) {
+			if matchOne(x, y) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func splitList(s string) []string {
+	var parts []string
+	for _, part := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ';' }) {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return parts
+}
+
+func matchOne(a, b string) bool {
+	ra, errA := ParseRange(a)
+	rb, errB := ParseRange(b)
+	if errA != nil || errB != nil {
+		return strings.EqualFold(a, b)
+	}
+	return ra.Overlaps(rb)
+}
+
+// Normalize returns the canonical form of a range, or s unchanged when it
+// does not parse.
+func Normalize(s string) string {
+	if r, err := ParseRange(s); err == nil {
+		return r.String()
+	}
+	return strings.TrimSpace(s)
+}
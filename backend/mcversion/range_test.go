@@ -0,0 +1,123 @@
+package mcversion
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseRange(t *testing.T) {
+	tests := []struct {
+		text  string
+		want  string
+		exact bool
+	}{
+		{"1.20.1", "1.20.1", true},
+		{"1.20-pre1", "1.20-pre1", true},
+		{"23w14a", "23w14a", true},
+		{"1.20.x", "1.20.x", false},
+		{"1.20.*", "1.20.x", false},
+		{"1.19–1.20.4", "1.19–1.20.4", false},
+		{"1.19 - 1.20.4", "1.19–1.20.4", false},
+		{"1.19-1.20.4", "1.19–1.20.4", false},
+		{"1.20.4-1.19", "1.19–1.20.4", false},
+		{"1.20-pre1-1.20.1", "1.20-pre1–1.20.1", false},
+		{"1.20.1–1.20.1", "1.20.1–1.20.1", true},
+		{">=1.18", ">=1.18", false},
+		{"1.18+", ">=1.18", false},
+		{"<1.20", "<1.20", false},
+	}
+	for _, tt := range tests {
+		r, err := ParseRange(tt.text)
+		if err != nil {
+			t.Errorf("ParseRange(%q): %v", tt.text, err)
+			continue
+		}
+		if r.String() != tt.want || r.IsExact() != tt.exact {
+			t.Errorf("ParseRange(%q) = %q exact %v, want %q exact %v", tt.text, r, r.IsExact(), tt.want, tt.exact)
+		}
+	}
+}
+
+func TestParseRangeInvalid(t *testing.T) {
+	for _, text := range []string{"", "  ", "Bedrock", "1.20.1.x", "1.20-pre1.x", ">=", "1.19–Bedrock", "1.20.1,"} {
+		if r, err := ParseRange(text); !errors.Is(err, ErrInvalidVersion) {
+			t.Errorf("ParseRange(%q) = %q, %v, want ErrInvalidVersion", text, r, err)
+		}
+	}
+}
+
+func TestRangeContains(t *testing.T) {
+	tests := []struct {
+		r, v string
+		want bool
+	}{
+		{"1.20.x", "1.20", true},
+		{"1.20.x", "1.20.6", true},
+		{"1.20.x", "1.21", false},
+		{"1.20.x", "24w14a", true},
+		{"1.20.x", "1.21-pre1", false},
+		{"1.20.x", "1.19.4", false},
+		{"1.19–1.20.4", "1.19", true},
+		{"1.19–1.20.4", "1.20.4", true},
+		{"1.19–1.20.4", "1.20.5", false},
+		{">=1.18", "1.21", true},
+		{">=1.18", "1.17.1", false},
+		{">1.18", "1.18", false},
+		{"<1.20", "1.20-rc1", true},
+		{"<1.20", "1.20", false},
+		{"<=1.20", "1.20", true},
+	}
+	for _, tt := range tests {
+		r, _ := ParseRange(tt.r)
+		v, _ := Parse(tt.v)
+		if got := r.Contains(v); got != tt.want {
+			t.Errorf("%s contains %s = %v, want %v", tt.r, tt.v, got, tt.want)
+		}
+	}
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"1.20.1", "1.20.1", true},
+		{"1.20.1", "1.20.2", false},
+		{"1.20.1", "1.20.x", true},
+		{"1.20.1", "1.19–1.20.4", true},
+		{"1.20.x", "1.19–1.20.1", true},
+		{"1.21", "1.19–1.20.4", false},
+		{">=1.18", "1.16.5", false},
+		{">=1.18", "1.18.2", true},
+		{"1.20.1, 1.20.4", "1.20.4", true},
+		{"1.20.1; 1.20.4", "1.20.2, 1.20.3", false},
+		{"23w14a", "1.20.x", false},
+		{"23w14a", "1.19.4–1.20", true},
+		{"24w14a", "1.20.x", true},
+		{"Bedrock", "bedrock", true},
+		{"Bedrock", "1.20.1", false},
+		{"", "1.20.1", false},
+	}
+	for _, tt := range tests {
+		if got := Match(tt.a, tt.b); got != tt.want {
+			t.Errorf("Match(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+		if got := Match(tt.b, tt.a); got != tt.want {
+			t.Errorf("Match(%q, %q) = %v, want %v", tt.b, tt.a, got, tt.want)
+		}
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := map[string]string{
+		" 1.20.1 ":      "1.20.1",
+		"1.19 - 1.20.4": "1.19–1.20.4",
+		"1.18+":         ">=1.18",
+		" Bedrock ":     "Bedrock",
+	}
+	for text, want := range tests {
+		if got := Normalize(text); got != want {
+			t.Errorf("Normalize(%q) = %q, want %q", text, got, want)
+		}
+	}
+}
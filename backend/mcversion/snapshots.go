@@ -0,0 +1,49 @@
+package mcversion
+
+// snapshotCycles maps the last snapshot week (YYWW) of each development
+// cycle to the release it led to.
+var snapshotCycles = []struct {
+	lastWeek int
+	release  Version
+}{
+	{1607, Version{Major: 1, Minor: 9, Kind: Release}},
+	{1615, Version{Major: 1, Minor: 9, Patch: 3, Kind: Release}},
+	{1621, Version{Major: 1, Minor: 10, Kind: Release}},
+	{1644, Version{Major: 1, Minor: 11, Kind: Release}},
+	{1718, Version{Major: 1, Minor: 12, Kind: Release}},
+	{1822, Version{Major: 1, Minor: 13, Kind: Release}},
+	{1833, Version{Major: 1, Minor: 13, Patch: 1, Kind: Release}},
+	{1914, Version{Major: 1, Minor: 14, Kind: Release}},
+	{1946, Version{Major: 1, Minor: 15, Kind: Release}},
+	{2022, Version{Major: 1, Minor: 16, Kind: Release}},
+	{2030, Version{Major: 1, Minor: 16, Patch: 2, Kind: Release}},
+	{2120, Version{Major: 1, Minor: 17, Kind: Release}},
+	{2144, Version{Major: 1, Minor: 18, Kind: Release}},
+	{2207, Version{Major: 1, Minor: 18, Patch: 2, Kind: Release}},
+	{2219, Version{Major: 1, Minor: 19, Kind: Release}},
+	{2224, Version{Major: 1, Minor: 19, Patch: 1, Kind: Release}},
+	{2246, Version{Major: 1, Minor: 19, Patch: 3, Kind: Release}},
+	{2307, Version{Major: 1, Minor: 19, Patch: 4, Kind: Release}},
+	{2318, Version{Major: 1, Minor: 20, Kind: Release}},
+	{2335, Version{Major: 1, Minor: 20, Patch: 2, Kind: Release}},
+	{2346, Version{Major: 1, Minor: 20, Patch: 3, Kind: Release}},
+	{2414, Version{Major: 1, Minor: 20, Patch: 5, Kind: Release}},
+	{2421, Version{Major: 1, Minor: 21, Kind: Release}},
+	{2440, Version{Major: 1, Minor: 21, Patch: 2, Kind: Release}},
+	{2446, Version{Major: 1, Minor: 21, Patch: 4, Kind: Release}},
+	{2510, Version{Major: 1, Minor: 21, Patch: 5, Kind: Release}},
+	{2521, Version{Major: 1, Minor: 21, Patch: 6, Kind: Release}},
+}
+
+// snapshotTarget returns the release a snapshot from the given week led to.
+// Snapshots newer than the table are placed just after its last release.
+func snapshotTarget(week int) Version {
+	for _, c := range snapshotCycles {
+		if week <= c.lastWeek {
+			return c.release
+		}
+	}
+	last := snapshotCycles[len(snapshotCycles)-1].release
+	last.Patch++
+	return last
+}
@@ -0,0 +1,133 @@
+// Package mcversion parses and orders Minecraft game versions: releases,
+// weekly snapshots, pre-releases and release candidates, and the ranges
+// mod sites use to say which of them a file supports.
+package mcversion
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidVersion = errors.New("invalid minecraft version")
+
+// Kind orders the builds leading up to a release.
+type Kind int
+
+const (
+	Snapshot Kind = iota
+	PreRelease
+	ReleaseCandidate
+	Release
+)
+
+// Version is a single game version. Snapshots carry the release they lead
+// up to in Major, Minor and Patch, so they sort just before it.
+type Version struct {
+	Major int
+	Minor int
+	Patch int
+	Kind  Kind
+	// Build is the pre-release or release candidate number, or for a
+	// snapshot its year, week and letter packed as YYWWL.
+	Build int
+}
+
+var (
+	releasePattern  = regexp.MustCompile(`^(\d+)\.(\d+)(?:\.(\d+))?(?:[- ](pre-release|pre|release candidate|rc)[- ]?(\d+))?$`)
+	snapshotPattern = regexp.MustCompile(`^(\d{2})w(\d{2})([a-z])$`)
+)
+
+func Parse(s string) (Version, error) {
+	text := strings.ToLower(strings.TrimSpace(s))
+
+	if m := snapshotPattern.FindStringSubmatch(text); m != nil {
+		year, _ := strconv.Atoi(m[1])
+		week, _ := strconv.Atoi(m[2])
+		v := snapshotTarget(year*100 + week)
+		v.Kind = Snapshot
+		v.Build = (year*100+week)*100 + int(m[3][0]-'a')
+		return v, nil
+	}
+
+	m := releasePattern.FindStringSubmatch(text)
+	if m == nil {
+		return Version{}, fmt.Errorf("%w: %q", ErrInvalidVersion, s)
+	}
+	v := Version{Kind: Release}
+	v.Major, _ = strconv.Atoi(m[1])
+	v.Minor, _ = strconv.Atoi(m[2])
+	if m[3] != "" {
+		v.Patch, _ = strconv.Atoi(m[3])
+	}
+	switch m[4] {
+	case "pre", "pre-release":
+		v.Kind = PreRelease
+	case "rc", "release candidate":
+		v.Kind = ReleaseCandidate
+	}
+	if m[5] != "" {
+		v.Build, _ = strconv.Atoi(m[5])
+	}
+	return v, nil
+}
+
+func (v Version) String() string {
+	switch v.Kind {
+	case Snapshot:
+		return fmt.Sprintf("%02dw%02d%c", v.Build/10000, v.Build/100%100, 'a'+rune(v.Build%100))
+	case PreRelease:
+		return fmt.Sprintf("%s-pre%d", v.release(), v.Build)
+	case ReleaseCandidate:
+		return fmt.Sprintf("%s-rc%d", v.release(), v.Build)
+	}
+	return v.release()
+}
+
+func (v Version) release() string {
+	if v.Patch == 0 {
+		return fmt.Sprintf("%d.%d", v.Major, v.Minor)
+	}
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+func Compare(a, b Version) int {
+	return cmp.Or(
+		cmp.Compare(a.Major, b.Major),
+		cmp.Compare(a.Minor, b.Minor),
+		cmp.Compare(a.Patch, b.Patch),
+		cmp.Compare(a.Kind, b.Kind),
+		cmp.Compare(a.Build, b.Build),
+	)
+}
+
+// CompareStrings orders version strings, putting the ones that do not
+// parse after all others in plain string order.
+func CompareStrings(a, b string) int {
+	va, errA := Parse(a)
+	vb, errB := Parse(b)
+	switch {
+	case errA == nil && errB == nil:
+		return Compare(va, vb)
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	}
+	return cmp.Compare(a, b)
+}
+
+// Sort orders versions from oldest to newest.
+func Sort(versions []string) {
+	slices.SortFunc(versions, CompareStrings)
+}
+
+// floor is the lowest version of the major.minor line, below its first
+// snapshot.
+func floor(major, minor int) Version {
+	return Version{Major: major, Minor: minor, Kind: Snapshot}
+}
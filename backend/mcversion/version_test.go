@@ -0,0 +1,79 @@
+package mcversion
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		text string
+		want Version
+		str  string
+	}{
+		{"1.20", Version{Major: 1, Minor: 20, Kind: Release}, "1.20"},
+		{"1.20.1", Version{Major: 1, Minor: 20, Patch: 1, Kind: Release}, "1.20.1"},
+		{" 1.9.4 ", Version{Major: 1, Minor: 9, Patch: 4, Kind: Release}, "1.9.4"},
+		{"1.20-pre1", Version{Major: 1, Minor: 20, Kind: PreRelease, Build: 1}, "1.20-pre1"},
+		{"1.20.2 Pre-Release 3", Version{Major: 1, Minor: 20, Patch: 2, Kind: PreRelease, Build: 3}, "1.20.2-pre3"},
+		{"1.20.1-rc1", Version{Major: 1, Minor: 20, Patch: 1, Kind: ReleaseCandidate, Build: 1}, "1.20.1-rc1"},
+		{"1.20 Release Candidate 2", Version{Major: 1, Minor: 20, Kind: ReleaseCandidate, Build: 2}, "1.20-rc2"},
+		{"23w14a", Version{Major: 1, Minor: 20, Kind: Snapshot, Build: 231400}, "23w14a"},
+		{"24W14B", Version{Major: 1, Minor: 20, Patch: 5, Kind: Snapshot, Build: 241401}, "24w14b"},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.text)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tt.text, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Parse(%q) = %+v, want %+v", tt.text, got, tt.want)
+		}
+		if got.String() != tt.str {
+			t.Errorf("Parse(%q).String() = %q, want %q", tt.text, got.String(), tt.str)
+		}
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, text := range []string{"", "1", "1.20.x", "Bedrock", "v1.20", "1.20.1,", "23w14", "1.20-beta1"} {
+		if v, err := Parse(text); !errors.Is(err, ErrInvalidVersion) {
+			t.Errorf("Parse(%q) = %+v, %v, want ErrInvalidVersion", text, v, err)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	// Each version is older than the next.
+	ordered := []string{
+		"1.8.9", "1.9", "1.12.2", "1.19.4",
+		"23w14a", "23w14b", "1.20-pre1", "1.20-pre2", "1.20-rc1", "1.20",
+		"1.20.1", "1.20.4", "24w14a", "1.20.5", "1.21",
+	}
+	for i := range ordered {
+		for j := range ordered {
+			a, _ := Parse(ordered[i])
+			b, _ := Parse(ordered[j])
+			want := 0
+			if i < j {
+				want = -1
+			} else if i > j {
+				want = 1
+			}
+			if got := Compare(a, b); got != want {
+				t.Errorf("Compare(%s, %s) = %d, want %d", ordered[i], ordered[j], got, want)
+			}
+		}
+	}
+}
+
+func TestSort(t *testing.T) {
+	versions := []string{"1.20", "Bedrock", "1.9", "1.20.1", "23w14a", "1.12.2", "Alpha"}
+	Sort(versions)
+	want := []string{"1.9", "1.12.2", "23w14a", "1.20", "1.20.1", "Alpha", "Bedrock"}
+	if !slices.Equal(versions, want) {
+		t.Errorf("Sort = %v, want %v", versions, want)
+	}
+}
@@ -0,0 +1,416 @@
+package nettools
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"log"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ResourceKind groups cached URLs that share a time to live.
+type ResourceKind string
+
+const (
+	KindListPage ResourceKind = "list"
+	KindPage     ResourceKind = "page"
+	KindAPI      ResourceKind = "api"
+	KindImage    ResourceKind = "image"
+	KindDownload ResourceKind = "download"
+)
+
+const (
+	headerStoredAt = "X-Cache-Stored-At"
+	headerCache    = "X-Cache"
+
+	DefaultCacheMaxBytes = 256 << 20
+)
+
+var DefaultCacheTTLs = map[ResourceKind]time.Duration{
+	KindListPage: 10 * time.Minute,
+	KindPage:     time.Hour,
+	KindAPI:      15 * time.Minute,
+	KindImage:    7 * 24 * time.Hour,
+}
+
+// DefaultPinnedKinds are the kinds whose last-known copy the offline mode
+// relies on.
+var DefaultPinnedKinds = []ResourceKind{KindListPage, KindPage, KindAPI}
+
+type CacheOptions struct {
+	Dir      string
+	MaxBytes int64
+	TTLs     map[ResourceKind]time.Duration
+	Pinned   []ResourceKind
+}
+
+type cacheItem struct {
+	path     string
+	size     int64
+	lastUsed time.Time
+	pinned   bool
+}
+
+// CacheTransport is an on-disk HTTP cache. Entries live for the TTL of
+// their resource kind and are then revalidated with ETag/Last-Modified.
+// Least recently used entries are evicted once the cache outgrows MaxBytes,
+// but entries of the Pinned kinds only once no other entry is left, so
+// images never push out the last-known copy of a page. Downloads and other
+// kinds without a TTL are never cached. When the site
+// cannot be reached or blocks the request, an expired entry is served as
+// stale instead of failing.
+type CacheTransport struct {
+	opts CacheOptions
+	Next http.RoundTripper
+
+	mu     sync.Mutex
+	loaded bool
+	items  map[string]*cacheItem
+	total  int64
+}
+
+func NewCacheTransport(opts CacheOptions, next http.RoundTripper) *CacheTransport {
+	if opts.MaxBytes <= 0 {
+		opts.MaxBytes = DefaultCacheMaxBytes
+	}
+	if opts.TTLs == nil {
+		opts.TTLs = DefaultCacheTTLs
+	}
+	if opts.Pinned == nil {
+		opts.Pinned = DefaultPinnedKinds
+	}
+	return &CacheTransport{opts: opts, Next: next, items: make(map[string]*cacheItem)}
+}
+
+func (t *CacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ttl := t.opts.TTLs[ClassifyURL(req)]
+	if req.Method != http.MethodGet || ttl <= 0 || req.Header.Get("Range") != "" {
+		return t.forward(req)
+	}
+
+	key := cacheKey(req)
+	cached, storedAt, ok := t.load(key, req)
+	if ok && time.Since(storedAt) < ttl {
+		cached.Header.Set(headerCache, "HIT")
+		return cached, nil
+	}
+
+	upstream := req
+	if ok {
+		upstream = conditionalRequest(req, cached)
+	}
+
+	resp, err := t.forward(upstream)
+	if err != nil {
+		if ok && IsUnreachable(err) && req.Context().Err() == nil {
+			return serveStale(req, cached, storedAt), nil
+		}
+		if ok {
+			cached.Body.Close()
+		}
+		return nil, err
+	}
+
+	if ok && isUnavailable(resp) {
+		resp.Body.Close()
+		return serveStale(req, cached, storedAt), nil
+	}
+
+	if ok && resp.StatusCode == http.StatusNotModified {
+		resp.Body.Close()
+		refreshHeaders(cached, resp)
+		cached.Header.Set(headerCache, "REVALIDATED")
+		t.store(key, cached)
+		return cached, nil
+	}
+	if ok {
+		cached.Body.Close()
+	}
+
+	switch {
+	case !storable(resp):
+		t.remove(key)
+	case resp.StatusCode == http.StatusOK:
+		resp.Header.Set(headerCache, "MISS")
+		t.store(key, resp)
+	}
+	return resp, nil
+}
+
+// storable reports whether the server allows resp to be kept on disk.
+func storable(resp *http.Response) bool {
+	for _, value := range resp.Header.Values("Cache-Control") {
+		for _, directive := range strings.Split(value, ",") {
+			name, _, _ := strings.Cut(strings.TrimSpace(directive), "=")
+			if strings.EqualFold(name, "no-store") || strings.EqualFold(name, "private") {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// notModifiedSkip are the headers of a 304 response that describe the
+// empty 304 body rather than the stored one.
+var notModifiedSkip = []string{"Content-Length", "Content-Encoding", "Content-Range", "Transfer-Encoding", "Set-Cookie"}
+
+// refreshHeaders updates the headers of a stored response with those of
+// the 304 that revalidated it, so new validators and expiry are kept.
+func refreshHeaders(cached, notModified *http.Response) {
+	for name, values := range notModified.Header {
+		if slices.Contains(notModifiedSkip, name) {
+			continue
+		}
+		cached.Header[name] = slices.Clone(values)
+	}
+}
+
+// forward sends req upstream and keeps the connectivity status up to date.
+func (t *CacheTransport) forward(req *http.Request) (*http.Response, error) {
+	resp, err := t.Next.RoundTrip(req)
+	switch {
+	case err != nil:
+		if IsUnreachable(err) && req.Context().Err() == nil {
+			markOffline()
+		}
+	case !isUnavailable(resp):
+		markOnline()
+	}
+	return resp, err
+}
+
+// serveStale answers req with an expired cache entry and reports its age to
+// the operation tracking staleness.
+func serveStale(req *http.Request, cached *http.Response, storedAt time.Time) *http.Response {
+	log.Printf("📦 Serving %s from cache stored %s ago", req.URL, time.Since(storedAt).Round(time.Second))
+	cached.Header.Set(headerCache, "STALE")
+	noteStale(req.Context(), storedAt)
+	return cached
+}
+
+// Clear removes every cached response.
+func (t *CacheTransport) Clear() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if err := os.RemoveAll(t.opts.Dir); err != nil {
+		return fmt.Errorf("failed to clear cache: %w", err)
+	}
+	t.items = make(map[string]*cacheItem)
+	t.total = 0
+	t.loaded = true
+	return nil
+}
+
+// Size returns the number of bytes the cache currently takes on disk.
+func (t *CacheTransport) Size() int64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.loadIndex()
+	return t.total
+}
+
+func (t *CacheTransport) load(key string, req *http.Request) (*http.Response, time.Time, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.loadIndex()
+
+	item, ok := t.items[key]
+	if !ok {
+		return nil, time.Time{}, false
+	}
+
+	data, err := os.ReadFile(item.path)
+	if err != nil {
+		t.forget(key)
+		return nil, time.Time{}, false
+	}
+	resp, err := readResponse(data, req)
+	if err != nil {
+		t.forget(key)
+		return nil, time.Time{}, false
+	}
+
+	storedAt, _ := time.Parse(time.RFC3339, resp.Header.Get(headerStoredAt))
+	item.lastUsed = time.Now()
+	os.Chtimes(item.path, item.lastUsed, item.lastUsed)
+	return resp, storedAt, true
+}
+
+// store writes resp to disk. Cookies are left out of the entry so a
+// replayed page never sets a session that has since changed; the caller
+// still sees them on resp.
+func (t *CacheTransport) store(key string, resp *http.Response) {
+	resp.Header.Set(headerStoredAt, time.Now().UTC().Format(time.RFC3339Nano))
+	cookies := resp.Header.Values("Set-Cookie")
+	resp.Header.Del("Set-Cookie")
+	dump, err := dumpResponse(resp)
+	for _, cookie := range cookies {
+		resp.Header.Add("Set-Cookie", cookie)
+	}
+	if err != nil {
+		log.Printf("⚠️ Failed to cache %s: %v", resp.Request.URL, err)
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.loadIndex()
+
+	kind := ClassifyURL(resp.Request)
+	path := t.path(kind, key)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		log.Printf("⚠️ Failed to create cache dir: %v", err)
+		return
+	}
+	if err := os.WriteFile(path, dump, 0644); err != nil {
+		log.Printf("⚠️ Failed to write cache entry: %v", err)
+		return
+	}
+
+	if item, ok := t.items[key]; ok && item.path != path {
+		os.Remove(item.path)
+	}
+	t.forget(key)
+	t.items[key] = &cacheItem{path: path, size: int64(len(dump)), lastUsed: time.Now(), pinned: slices.Contains(t.opts.Pinned, kind)}
+	t.total += int64(len(dump))
+	t.evict()
+}
+
+// remove drops the entry for key, if there is one.
+func (t *CacheTransport) remove(key string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.loadIndex()
+
+	if item, ok := t.items[key]; ok {
+		os.Remove(item.path)
+		t.forget(key)
+	}
+}
+
+// evict removes least recently used entries until the cache fits, taking
+// pinned entries last.
+func (t *CacheTransport) evict() {
+	if t.total <= t.opts.MaxBytes {
+		return
+	}
+
+	keys := make([]string, 0, len(t.items))
+	for key := range t.items {
+		keys = append(keys, key)
+	}
+	slices.SortFunc(keys, func(a, b string) int {
+		ia, ib := t.items[a], t.items[b]
+		if ia.pinned != ib.pinned {
+			if ia.pinned {
+				return 1
+			}
+			return -1
+		}
+		return ia.lastUsed.Compare(ib.lastUsed)
+	})
+
+	for _, key := range keys {
+		if t.total <= t.opts.MaxBytes {
+			return
+		}
+		os.Remove(t.items[key].path)
+		t.forget(key)
+	}
+}
+
+func (t *CacheTransport) forget(key string) {
+	if item, ok := t.items[key]; ok {
+		t.total -= item.size
+		delete(t.items, key)
+	}
+}
+
+// loadIndex builds the in-memory index from the cache directory once,
+// using file modification times as last-use times. Entries are stored
+// under a directory per resource kind, which tells whether they are
+// pinned.
+func (t *CacheTransport) loadIndex() {
+	if t.loaded {
+		return
+	}
+	t.loaded = true
+
+	filepath.WalkDir(t.opts.Dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || filepath.Ext(path) != ".http" {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		key := strings.TrimSuffix(filepath.Base(path), ".http")
+		rel, _ := filepath.Rel(t.opts.Dir, path)
+		kind, _, _ := strings.Cut(filepath.ToSlash(rel), "/")
+		t.items[key] = &cacheItem{
+			path:     path,
+			size:     info.Size(),
+			lastUsed: info.ModTime(),
+			pinned:   slices.Contains(t.opts.Pinned, ResourceKind(kind)),
+		}
+		t.total += info.Size()
+		return nil
+	})
+}
+
+func (t *CacheTransport) path(kind ResourceKind, key string) string {
+	return filepath.Join(t.opts.Dir, string(kind), key[:2], key+".http")
+}
+
+func cacheKey(req *http.Request) string {
+	sum := sha1.Sum([]byte(req.URL.String() + "|" + req.Header.Get("Accept-Encoding")))
+	return hex.EncodeToString(sum[:])
+}
+
+func conditionalRequest(req *http.Request, cached *http.Response) *http.Request {
+	etag := cached.Header.Get("ETag")
+	lastModified := cached.Header.Get("Last-Modified")
+	if etag == "" && lastModified == "" {
+		return req
+	}
+
+	conditional := req.Clone(req.Context())
+	if etag != "" {
+		conditional.Header.Set("If-None-Match", etag)
+	}
+	if lastModified != "" {
+		conditional.Header.Set("If-Modified-Since", lastModified)
+	}
+	return conditional
+}
+
+var imageExtensions = []string{".png", ".jpg", ".jpeg", ".gif", ".webp", ".svg"}
+
+// ClassifyURL decides which resource kind, and therefore which TTL, a
+// request falls under.
+func ClassifyURL(req *http.Request) ResourceKind {
+	p := strings.ToLower(req.URL.Path)
+	ext := path.Ext(p)
+
+	switch {
+	case slices.Contains(imageExtensions, ext):
+		return KindImage
+	case ext == ".jar" || ext == ".zip" || strings.Contains(p, "/download/"):
+		return KindDownload
+	case strings.HasPrefix(req.URL.Host, "api."):
+		return KindAPI
+	case strings.Contains(p, "/page/"):
+		return KindListPage
+	default:
+		return KindPage
+	}
+}
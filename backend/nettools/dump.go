@@ -0,0 +1,68 @@
+package nettools
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"regexp"
+	"strconv"
+)
+
+// cloudflareToken matches Cloudflare cookies and challenge tokens, such as
+// cf_clearance=... or __cf_bm=..., up to the end of their value.
+var cloudflareToken = regexp.MustCompile(`(?i)\b(__cf[a-z0-9_]*|cf_[a-z0-9_]+)=([^;&"'\s<>]+)`)
+
+const redacted = "REDACTED"
+
+// dumpFixture serialises resp like dumpResponse, leaving out what must not
+// be committed with a fixture: Set-Cookie headers and the values of
+// Cloudflare tokens, wherever they appear.
+func dumpFixture(resp *http.Response) ([]byte, error) {
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	fixture := *resp
+	fixture.Header = resp.Header.Clone()
+	fixture.Header.Del("Set-Cookie")
+	for name, values := range fixture.Header {
+		for i, v := range values {
+			values[i] = scrubTokens(v)
+		}
+		fixture.Header[name] = values
+	}
+	fixture.Body = io.NopCloser(bytes.NewReader([]byte(scrubTokens(string(body)))))
+	return dumpResponse(&fixture)
+}
+
+func scrubTokens(s string) string {
+	return cloudflareToken.ReplaceAllString(s, "${1}="+redacted)
+}
+
+// dumpResponse serialises resp with a fixed Content-Length, since a body
+// the transport already decompressed has no length of its own. resp keeps
+// an equivalent, unread body.
+func dumpResponse(resp *http.Response) ([]byte, error) {
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	resp.ContentLength = int64(len(body))
+	resp.TransferEncoding = nil
+	resp.Uncompressed = false
+	resp.Header.Set("Content-Length", strconv.Itoa(len(body)))
+
+	return httputil.DumpResponse(resp, true)
+}
+
+func readResponse(data []byte, req *http.Request) (*http.Response, error) {
+	return http.ReadResponse(bufio.NewReader(bytes.NewReader(data)), req)
+}
@@ -0,0 +1,218 @@
+package nettools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+var ErrCircuitOpen = errors.New("circuit_open: too many failed requests, paused for a while")
+
+type GovernorOptions struct {
+	// Rate and Burst configure the per-domain token bucket.
+	Rate  float64
+	Burst int
+
+	// MaxRetries is how often a 429 or 503 response is retried after
+	// waiting for Retry-After or the adaptive backoff.
+	MaxRetries  int
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+
+	// After BreakerThreshold consecutive failures requests to the domain
+	// fail fast with ErrCircuitOpen for BreakerCooldown.
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
+}
+
+var DefaultGovernorOptions = GovernorOptions{
+	Rate:             2,
+	Burst:            4,
+	MaxRetries:       3,
+	BaseBackoff:      5 * time.Second,
+	MaxBackoff:       time.Minute,
+	BreakerThreshold: 5,
+	BreakerCooldown:  time.Minute,
+}
+
+type domainState struct {
+	tokens       float64
+	refilledAt   time.Time
+	blockedUntil time.Time
+	backoff      time.Duration
+	failures     int
+	openUntil    time.Time
+}
+
+// Governor paces requests per domain so every collector and download in the
+// process shares one budget towards each site.
+type Governor struct {
+	opts GovernorOptions
+	Next http.RoundTripper
+
+	mu      sync.Mutex
+	domains map[string]*domainState
+}
+
+func NewGovernor(opts GovernorOptions, next http.RoundTripper) *Governor {
+	return &Governor{opts: opts, Next: next, domains: make(map[string]*domainState)}
+}
+
+func (g *Governor) RoundTrip(req *http.Request) (*http.Response, error) {
+	host := req.URL.Hostname()
+
+	for attempt := 0; ; attempt++ {
+		if err := g.wait(req.Context(), host); err != nil {
+			return nil, err
+		}
+
+		resp, err := g.Next.RoundTrip(req)
+		if err != nil {
+			if req.Context().Err() == nil {
+				g.failure(host)
+			}
+			return nil, err
+		}
+
+		if !isThrottled(resp) {
+			if resp.StatusCode >= 500 {
+				g.failure(host)
+			} else {
+				g.success(host)
+			}
+			return resp, nil
+		}
+
+		delay := g.throttle(host, resp)
+		canRetry := req.Body == nil || req.GetBody != nil
+		if attempt >= g.opts.MaxRetries || !canRetry {
+			g.failure(host)
+			return resp, nil
+		}
+
+		log.Printf("⏳ Got %d from %s. Retrying after %s...", resp.StatusCode, req.URL, delay)
+		resp.Body.Close()
+		if req.GetBody != nil {
+			if req.Body, err = req.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// wait blocks until host has a token and is not backing off, or fails fast
+// while the host's circuit is open.
+func (g *Governor) wait(ctx context.Context, host string) error {
+	for {
+		g.mu.Lock()
+		state := g.state(host)
+		now := time.Now()
+
+		if now.Before(state.openUntil) {
+			g.mu.Unlock()
+			return fmt.Errorf("%w (%s)", ErrCircuitOpen, host)
+		}
+
+		var delay time.Duration
+		if now.Before(state.blockedUntil) {
+			delay = state.blockedUntil.Sub(now)
+		} else {
+			g.refill(state, now)
+			if state.tokens >= 1 {
+				state.tokens--
+				g.mu.Unlock()
+				return nil
+			}
+			delay = time.Duration((1 - state.tokens) / g.opts.Rate * float64(time.Second))
+		}
+		g.mu.Unlock()
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (g *Governor) refill(state *domainState, now time.Time) {
+	elapsed := now.Sub(state.refilledAt).Seconds()
+	state.tokens = min(float64(g.opts.Burst), state.tokens+elapsed*g.opts.Rate)
+	state.refilledAt = now
+}
+
+// throttle blocks host for the server's Retry-After, or for an adaptive
+// backoff that doubles with every throttled response in a row.
+func (g *Governor) throttle(host string, resp *http.Response) time.Duration {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	state := g.state(host)
+	if state.backoff == 0 {
+		state.backoff = g.opts.BaseBackoff
+	} else {
+		state.backoff = min(state.backoff*2, g.opts.MaxBackoff)
+	}
+
+	delay := state.backoff
+	if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+		delay = retryAfter
+	}
+
+	state.blockedUntil = time.Now().Add(delay)
+	return delay
+}
+
+func (g *Governor) success(host string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	state := g.state(host)
+	state.failures = 0
+	state.backoff = 0
+}
+
+func (g *Governor) failure(host string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	state := g.state(host)
+	state.failures++
+	if state.failures >= g.opts.BreakerThreshold {
+		log.Printf("🚧 %d failed requests to %s in a row, pausing for %s", state.failures, host, g.opts.BreakerCooldown)
+		state.openUntil = time.Now().Add(g.opts.BreakerCooldown)
+		state.failures = 0
+	}
+}
+
+func (g *Governor) state(host string) *domainState {
+	state, ok := g.domains[host]
+	if !ok {
+		state = &domainState{tokens: float64(g.opts.Burst), refilledAt: time.Now()}
+		g.domains[host] = state
+	}
+	return state
+}
+
+func isThrottled(resp *http.Response) bool {
+	return resp.StatusCode == http.StatusTooManyRequests ||
+		(resp.StatusCode == http.StatusServiceUnavailable && resp.Header.Get("Retry-After") != "")
+}
+
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(time.Until(at), 0), true
+	}
+	return 0, false
+}
@@ -0,0 +1,62 @@
+package nettools
+
+import (
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+)
+
+// ImageProxyPath is the asset server route the frontend loads remote
+// images through, as ImageProxyPath + "?url=" + the escaped image URL.
+const ImageProxyPath = "/cache/image"
+
+// ImageProxy serves remote images through the shared transport, so icons
+// and screenshots are cached with the pages and still show offline. Only
+// http(s) URLs of images are fetched.
+type ImageProxy struct{}
+
+func NewImageProxy() *ImageProxy {
+	return &ImageProxy{}
+}
+
+func (p *ImageProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != ImageProxyPath || r.Method != http.MethodGet {
+		http.NotFound(w, r)
+		return
+	}
+
+	target, err := url.Parse(r.URL.Query().Get("url"))
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") {
+		http.Error(w, "invalid image url", http.StatusBadRequest)
+		return
+	}
+
+	req, err := http.NewRequestWithContext(r.Context(), http.MethodGet, target.String(), nil)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if ClassifyURL(req) != KindImage {
+		http.Error(w, "not an image url", http.StatusBadRequest)
+		return
+	}
+	ApplyHeaders(req.Header, req.URL)
+
+	resp, err := Transport().RoundTrip(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer resp.Body.Close()
+
+	for _, name := range []string{"Content-Type", "Content-Length", "Last-Modified", "ETag", headerCache} {
+		if value := resp.Header.Get(name); value != "" {
+			w.Header().Set(name, value)
+		}
+	}
+	w.WriteHeader(resp.StatusCode)
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		log.Printf("⚠️ Failed to serve image %s: %v", target, err)
+	}
+}
@@ -0,0 +1,207 @@
+package nettools
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lanxre/mc-launcher/backend/appdata"
+	"gopkg.in/yaml.v3"
+)
+
+const cookiesFile = "cookies.yaml"
+
+// storedCookie is a cookie as kept on disk. HostOnly cookies were set
+// without a Domain attribute and only go back to that exact host.
+type storedCookie struct {
+	Name     string    `yaml:"name"`
+	Value    string    `yaml:"value"`
+	Domain   string    `yaml:"domain"`
+	Path     string    `yaml:"path"`
+	Expires  time.Time `yaml:"expires,omitempty"`
+	Secure   bool      `yaml:"secure"`
+	HttpOnly bool      `yaml:"http_only"`
+	HostOnly bool      `yaml:"host_only"`
+}
+
+func (c storedCookie) key() string {
+	return c.Domain + ";" + c.Path + ";" + c.Name
+}
+
+func (c storedCookie) expired() bool {
+	return !c.Expires.IsZero() && time.Now().After(c.Expires)
+}
+
+// PersistentJar is a cookie jar that writes every cookie it receives to
+// disk, so sessions and Cloudflare clearance survive restarts.
+type PersistentJar struct {
+	path string
+
+	mu      sync.Mutex
+	inner   *cookiejar.Jar
+	cookies map[string]storedCookie
+}
+
+var (
+	jarOnce sync.Once
+	jar     *PersistentJar
+)
+
+// Jar returns the cookie jar shared by every collector and HTTP client
+// that talks to the scraped sites.
+func Jar() *PersistentJar {
+	jarOnce.Do(func() {
+		path := ""
+		if dir, err := appdata.Dir(); err != nil {
+			log.Printf("⚠️ Cookies will not be saved: %v", err)
+		} else {
+			path = filepath.Join(dir, cookiesFile)
+		}
+		jar = newPersistentJar(path)
+	})
+	return jar
+}
+
+func newPersistentJar(path string) *PersistentJar {
+	inner, _ := cookiejar.New(nil)
+	j := &PersistentJar{path: path, inner: inner, cookies: make(map[string]storedCookie)}
+	if err := j.load(); err != nil {
+		log.Printf("⚠️ Failed to load cookies: %v", err)
+	}
+	return j
+}
+
+func (j *PersistentJar) Cookies(u *url.URL) []*http.Cookie {
+	j.mu.Lock()
+	inner := j.inner
+	j.mu.Unlock()
+	return inner.Cookies(u)
+}
+
+func (j *PersistentJar) SetCookies(u *url.URL, cookies []*http.Cookie) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.inner.SetCookies(u, cookies)
+	for _, c := range cookies {
+		stored := toStored(u, c)
+		if stored.expired() || c.MaxAge < 0 {
+			delete(j.cookies, stored.key())
+			continue
+		}
+		j.cookies[stored.key()] = stored
+	}
+	if err := j.save(); err != nil {
+		log.Printf("⚠️ Failed to save cookies: %v", err)
+	}
+}
+
+// Lookup returns the unexpired cookie called name that is sent to host.
+func (j *PersistentJar) Lookup(host, name string) (*http.Cookie, time.Time, bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	for _, c := range j.cookies {
+		domain := strings.TrimPrefix(c.Domain, ".")
+		matches := host == domain || (!c.HostOnly && strings.HasSuffix(host, "."+domain))
+		if c.Name == name && matches && !c.expired() {
+			return &http.Cookie{Name: c.Name, Value: c.Value}, c.Expires, true
+		}
+	}
+	return nil, time.Time{}, false
+}
+
+// Clear forgets every cookie.
+func (j *PersistentJar) Clear() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.inner, _ = cookiejar.New(nil)
+	j.cookies = make(map[string]storedCookie)
+	return j.save()
+}
+
+func toStored(u *url.URL, c *http.Cookie) storedCookie {
+	stored := storedCookie{
+		Name:     c.Name,
+		Value:    c.Value,
+		Domain:   strings.ToLower(c.Domain),
+		Path:     c.Path,
+		Expires:  c.Expires,
+		Secure:   c.Secure,
+		HttpOnly: c.HttpOnly,
+	}
+	if stored.Domain == "" {
+		stored.Domain = u.Hostname()
+		stored.HostOnly = true
+	}
+	if stored.Path == "" {
+		stored.Path = "/"
+	}
+	if c.MaxAge > 0 {
+		stored.Expires = time.Now().Add(time.Duration(c.MaxAge) * time.Second)
+	}
+	return stored
+}
+
+func (j *PersistentJar) load() error {
+	if j.path == "" {
+		return nil
+	}
+	data, err := os.ReadFile(j.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read cookies: %w", err)
+	}
+
+	var cookies []storedCookie
+	if err := yaml.Unmarshal(data, &cookies); err != nil {
+		return fmt.Errorf("invalid cookies file %s: %w", j.path, err)
+	}
+
+	for _, c := range cookies {
+		if c.expired() {
+			continue
+		}
+		j.cookies[c.key()] = c
+		u := &url.URL{Scheme: "https", Host: strings.TrimPrefix(c.Domain, "."), Path: c.Path}
+		cookie := &http.Cookie{Name: c.Name, Value: c.Value, Path: c.Path, Expires: c.Expires, Secure: c.Secure, HttpOnly: c.HttpOnly}
+		if !c.HostOnly {
+			cookie.Domain = c.Domain
+		}
+		j.inner.SetCookies(u, []*http.Cookie{cookie})
+	}
+	return nil
+}
+
+// save writes the cookies to disk. The caller must hold j.mu.
+func (j *PersistentJar) save() error {
+	if j.path == "" {
+		return nil
+	}
+
+	cookies := make([]storedCookie, 0, len(j.cookies))
+	for _, c := range j.cookies {
+		cookies = append(cookies, c)
+	}
+	data, err := yaml.Marshal(cookies)
+	if err != nil {
+		return fmt.Errorf("failed to marshal cookies: %w", err)
+	}
+
+	tmp := j.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write cookies: %w", err)
+	}
+	if err := os.Rename(tmp, j.path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write cookies: %w", err)
+	}
+	return nil
+}
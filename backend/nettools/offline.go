@@ -0,0 +1,116 @@
+package nettools
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ConnectivityStatus says whether the last requests failed to reach the
+// network, and since when.
+type ConnectivityStatus struct {
+	Offline bool      `yaml:"offline"`
+	Since   time.Time `yaml:"since"`
+}
+
+type connectivityState struct {
+	mu          sync.Mutex
+	status      ConnectivityStatus
+	onReconnect []func()
+}
+
+var connectivity connectivityState
+
+// Connectivity returns the current connectivity status.
+func Connectivity() ConnectivityStatus {
+	connectivity.mu.Lock()
+	defer connectivity.mu.Unlock()
+	return connectivity.status
+}
+
+// OnReconnect registers fn to run, in its own goroutine, whenever a request
+// succeeds after the network was unreachable.
+func OnReconnect(fn func()) {
+	connectivity.mu.Lock()
+	defer connectivity.mu.Unlock()
+	connectivity.onReconnect = append(connectivity.onReconnect, fn)
+}
+
+func markOffline() {
+	connectivity.mu.Lock()
+	defer connectivity.mu.Unlock()
+	if !connectivity.status.Offline {
+		connectivity.status = ConnectivityStatus{Offline: true, Since: time.Now()}
+	}
+}
+
+func markOnline() {
+	connectivity.mu.Lock()
+	if !connectivity.status.Offline {
+		connectivity.mu.Unlock()
+		return
+	}
+	connectivity.status = ConnectivityStatus{Since: time.Now()}
+	listeners := append([]func(){}, connectivity.onReconnect...)
+	connectivity.mu.Unlock()
+
+	for _, fn := range listeners {
+		go fn()
+	}
+}
+
+// IsUnreachable reports whether err means the site could not be reached at
+// all, as opposed to a cancelled request or an error response.
+func IsUnreachable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	var netErr net.Error
+	return errors.Is(err, ErrCircuitOpen) || errors.As(err, &netErr)
+}
+
+// isUnavailable reports whether resp is an error page served instead of the
+// content: a server error, a rate limit or a Cloudflare block.
+func isUnavailable(resp *http.Response) bool {
+	return resp.StatusCode >= 500 || resp.StatusCode == http.StatusForbidden ||
+		resp.StatusCode == http.StatusTooManyRequests
+}
+
+type staleKey struct{}
+
+// Staleness collects the age of the cached responses served in place of
+// unreachable pages during one operation.
+type Staleness struct {
+	mu     sync.Mutex
+	oldest time.Time
+}
+
+// TrackStaleness returns a context whose requests report to the returned
+// Staleness when they are answered from the offline cache.
+func TrackStaleness(ctx context.Context) (context.Context, *Staleness) {
+	s := &Staleness{}
+	return context.WithValue(ctx, staleKey{}, s), s
+}
+
+// Oldest returns when the oldest stale response was stored, or false when
+// every response was fresh.
+func (s *Staleness) Oldest() (time.Time, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.oldest, !s.oldest.IsZero()
+}
+
+func noteStale(ctx context.Context, storedAt time.Time) {
+	s, ok := ctx.Value(staleKey{}).(*Staleness)
+	if !ok {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.oldest.IsZero() || storedAt.Before(s.oldest) {
+		s.oldest = storedAt
+	}
+}
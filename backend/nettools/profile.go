@@ -0,0 +1,138 @@
+package nettools
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+const DefaultProfile = "chrome-windows"
+
+// HeaderProfile describes the browser the launcher presents itself as to
+// the scraped sites. Extra holds further headers such as client hints.
+type HeaderProfile struct {
+	Name           string            `yaml:"name"`
+	UserAgent      string            `yaml:"user_agent"`
+	Accept         string            `yaml:"accept"`
+	AcceptLanguage string            `yaml:"accept_language"`
+	Extra          map[string]string `yaml:"extra"`
+}
+
+const (
+	browserAccept  = "text/html,application/xhtml+xml,application/xml;q=0.9,image/avif,image/webp,*/*;q=0.8"
+	acceptLanguage = "ru,en;q=0.9,ru-RU;q=0.8,en-US;q=0.7"
+	chromeVersion  = "141"
+	firefoxVersion = "143.0"
+)
+
+// chromeProfile builds the headers Chrome sends on the given platform, as
+// named in the sec-ch-ua-platform client hint.
+func chromeProfile(name, platform, osToken string) HeaderProfile {
+	return HeaderProfile{
+		Name:           name,
+		UserAgent:      fmt.Sprintf("Mozilla/5.0 (%s) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/%s.0.0.0 Safari/537.36", osToken, chromeVersion),
+		Accept:         browserAccept,
+		AcceptLanguage: acceptLanguage,
+		Extra: map[string]string{
+			"sec-ch-ua":          fmt.Sprintf(`"Google Chrome";v="%[1]s", "Not?A_Brand";v="8", "Chromium";v="%[1]s"`, chromeVersion),
+			"sec-ch-ua-mobile":   "?0",
+			"sec-ch-ua-platform": fmt.Sprintf("%q", platform),
+		},
+	}
+}
+
+func firefoxProfile(name, osToken string) HeaderProfile {
+	return HeaderProfile{
+		Name:           name,
+		UserAgent:      fmt.Sprintf("Mozilla/5.0 (%s; rv:%[2]s) Gecko/20100101 Firefox/%[2]s", osToken, firefoxVersion),
+		Accept:         browserAccept,
+		AcceptLanguage: acceptLanguage,
+	}
+}
+
+var builtinProfiles = []HeaderProfile{
+	chromeProfile("chrome-windows", "Windows", "Windows NT 10.0; Win64; x64"),
+	chromeProfile("chrome-linux", "Linux", "X11; Linux x86_64"),
+	chromeProfile("chrome-macos", "macOS", "Macintosh; Intel Mac OS X 10_15_7"),
+	firefoxProfile("firefox-windows", "Windows NT 10.0; Win64; x64"),
+	firefoxProfile("firefox-linux", "X11; Linux x86_64"),
+}
+
+// Profiles returns the built-in header profiles.
+func Profiles() []HeaderProfile {
+	return append([]HeaderProfile(nil), builtinProfiles...)
+}
+
+// LookupProfile returns the built-in profile called name, or the default
+// one if there is none.
+func LookupProfile(name string) HeaderProfile {
+	for _, p := range builtinProfiles {
+		if p.Name == name {
+			return p
+		}
+	}
+	return builtinProfiles[0]
+}
+
+var (
+	profileMu      sync.RWMutex
+	currentProfile = LookupProfile(DefaultProfile)
+	userAgent      string // overrides the profile, see SetUserAgent
+)
+
+// SetProfile makes p the profile ApplyHeaders uses.
+func SetProfile(p HeaderProfile) {
+	profileMu.Lock()
+	defer profileMu.Unlock()
+	currentProfile = p
+}
+
+// SetUserAgent overrides the user agent of the profile, e.g. with the one a
+// Cloudflare clearance was issued for. An empty ua restores the profile's.
+func SetUserAgent(ua string) {
+	profileMu.Lock()
+	defer profileMu.Unlock()
+	userAgent = ua
+}
+
+// CurrentProfile returns the profile in use, with any user agent override
+// applied.
+func CurrentProfile() HeaderProfile {
+	profileMu.RLock()
+	defer profileMu.RUnlock()
+	p := currentProfile
+	if userAgent != "" {
+		p.UserAgent = userAgent
+	}
+	return p
+}
+
+// ApplyHeaders sets the headers of the current profile on a request to
+// target, with a same-site referer unless one is already set.
+func ApplyHeaders(h http.Header, target *url.URL) {
+	p := CurrentProfile()
+	h.Set("User-Agent", p.UserAgent)
+	if p.Accept != "" {
+		h.Set("Accept", p.Accept)
+	}
+	if p.AcceptLanguage != "" {
+		h.Set("Accept-Language", p.AcceptLanguage)
+	}
+	for k, v := range p.Extra {
+		h.Set(k, v)
+	}
+	// Client hints only go with Chrome's user agent, not an override
+	// from another browser.
+	if !strings.Contains(p.UserAgent, "Chrome/") {
+		for k := range p.Extra {
+			if strings.HasPrefix(strings.ToLower(k), "sec-ch-ua") {
+				h.Del(k)
+			}
+		}
+	}
+	if h.Get("Referer") == "" && target != nil {
+		h.Set("Referer", target.Scheme+"://"+target.Host+"/")
+	}
+}
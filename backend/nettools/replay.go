@@ -0,0 +1,110 @@
+package nettools
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+type ReplayMode string
+
+const (
+	ModeLive   ReplayMode = "live"
+	ModeRecord ReplayMode = "record"
+	ModeReplay ReplayMode = "replay"
+)
+
+var ErrFixtureMissing = errors.New("no recorded fixture for request")
+
+var unsafeFixtureChars = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// ReplayTransport records responses to Dir as raw HTTP dumps in record
+// mode, without cookies or Cloudflare tokens, and serves them back without
+// touching the network in replay mode.
+type ReplayTransport struct {
+	Mode ReplayMode
+	Dir  string
+	Next http.RoundTripper
+
+	mu sync.Mutex
+}
+
+func NewReplayTransport(mode ReplayMode, dir string, next http.RoundTripper) *ReplayTransport {
+	return &ReplayTransport{Mode: mode, Dir: dir, Next: next}
+}
+
+func (t *ReplayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	switch t.Mode {
+	case ModeReplay:
+		return t.replay(req)
+	case ModeRecord:
+		return t.record(req)
+	default:
+		return t.Next.RoundTrip(req)
+	}
+}
+
+func (t *ReplayTransport) replay(req *http.Request) (*http.Response, error) {
+	path := t.FixturePath(req)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %s %s (%s)", ErrFixtureMissing, req.Method, req.URL, path)
+		}
+		return nil, fmt.Errorf("failed to read fixture: %w", err)
+	}
+
+	resp, err := readResponse(data, req)
+	if err != nil {
+		return nil, fmt.Errorf("invalid fixture %s: %w", path, err)
+	}
+	return resp, nil
+}
+
+func (t *ReplayTransport) record(req *http.Request) (*http.Response, error) {
+	resp, err := t.Next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	dump, err := dumpFixture(resp)
+	if err != nil {
+		return resp, fmt.Errorf("failed to dump response: %w", err)
+	}
+
+	path := t.FixturePath(req)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return resp, fmt.Errorf("failed to create fixtures dir: %w", err)
+	}
+	if err := os.WriteFile(path, dump, 0644); err != nil {
+		return resp, fmt.Errorf("failed to write fixture: %w", err)
+	}
+	return resp, nil
+}
+
+// FixturePath returns where the response to req is stored: one directory
+// per host and a file named after the path, suffixed with a hash of the
+// method and full URL so query strings do not collide.
+func (t *ReplayTransport) FixturePath(req *http.Request) string {
+	sum := sha1.Sum([]byte(req.Method + " " + req.URL.String()))
+
+	name := strings.Trim(unsafeFixtureChars.ReplaceAllString(req.URL.Path, "_"), "_")
+	if name == "" {
+		name = "index"
+	}
+	if len(name) > 80 {
+		name = name[:80]
+	}
+
+	host := unsafeFixtureChars.ReplaceAllString(req.URL.Host, "_")
+	return filepath.Join(t.Dir, host, fmt.Sprintf("%s_%s.http", name, hex.EncodeToString(sum[:6])))
+}
@@ -0,0 +1,91 @@
+package nettools
+
+import (
+	"log"
+	"net/http"
+	"os"
+	"sync"
+
+	"github.com/lanxre/mc-launcher/backend/appdata"
+)
+
+const (
+	envHTTPMode    = "MC_LAUNCHER_HTTP_MODE"
+	envFixturesDir = "MC_LAUNCHER_FIXTURES"
+
+	defaultFixturesDir = "testdata/fixtures"
+)
+
+var (
+	transportOnce sync.Once
+	transport     http.RoundTripper
+	cache         *CacheTransport
+)
+
+// Transport returns the round tripper every collector and HTTP client in
+// the launcher shares, so they all go through one Governor. The
+// record/replay mode is read once from the MC_LAUNCHER_HTTP_MODE and
+// MC_LAUNCHER_FIXTURES environment variables; the response cache is only
+// used in live mode so fixtures stay exact.
+func Transport() http.RoundTripper {
+	transportOnce.Do(func() {
+		transport = buildTransport(ReplayMode(os.Getenv(envHTTPMode)), os.Getenv(envFixturesDir))
+	})
+	return transport
+}
+
+// UseReplay replaces the shared transport with one in the given mode. It
+// must be called before the first request, as the parser tests' TestMain
+// does.
+func UseReplay(mode ReplayMode, dir string) {
+	transportOnce.Do(func() {})
+	transport = buildTransport(mode, dir)
+}
+
+// ClearCache drops every cached response.
+func ClearCache() error {
+	Transport()
+	if cache == nil {
+		return nil
+	}
+	return cache.Clear()
+}
+
+// CacheSize returns the size of the response cache on disk in bytes.
+func CacheSize() int64 {
+	Transport()
+	if cache == nil {
+		return 0
+	}
+	return cache.Size()
+}
+
+func buildTransport(mode ReplayMode, dir string) http.RoundTripper {
+	base := http.DefaultTransport.(*http.Transport).Clone()
+	if mode == ModeReplay {
+		cache = nil
+		return NewReplayTransport(mode, fixturesDir(dir), base)
+	}
+
+	var rt http.RoundTripper = NewGovernor(DefaultGovernorOptions, base)
+	if mode == ModeRecord {
+		cache = nil
+		return NewReplayTransport(mode, fixturesDir(dir), rt)
+	}
+
+	cacheDir, err := appdata.Dir("cache", "http")
+	if err != nil {
+		log.Printf("⚠️ HTTP cache disabled: %v", err)
+		cache = nil
+		return rt
+	}
+	cache = NewCacheTransport(CacheOptions{Dir: cacheDir}, rt)
+	return cache
+}
+
+func fixturesDir(dir string) string {
+	if dir == "" {
+		return defaultFixturesDir
+	}
+	return dir
+}
@@ -0,0 +1,128 @@
+package operations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+var ErrCancelled = errors.New("cancelled: operation was cancelled")
+
+type operation struct {
+	cancel context.CancelFunc
+	seq    uint64
+}
+
+// Registry tracks running operations by ID so they can be cancelled from
+// the frontend, and cancels all of them when the app shuts down.
+type Registry struct {
+	mu     sync.Mutex
+	base   context.Context
+	cancel context.CancelFunc
+	ops    map[string]operation
+	seq    atomic.Uint64
+}
+
+func NewRegistry() *Registry {
+	base, cancel := context.WithCancel(context.Background())
+	return &Registry{
+		base:   base,
+		cancel: cancel,
+		ops:    make(map[string]operation),
+	}
+}
+
+// Bind makes ctx, usually the Wails app context, the parent of every
+// operation started afterwards.
+func (r *Registry) Bind(ctx context.Context) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.base, r.cancel = context.WithCancel(ctx)
+}
+
+// Start registers a new operation. Starting an ID that is still running
+// cancels the earlier operation, so a repeated ID like "search" always
+// keeps only the latest request alive. An empty id gets a generated one.
+func (r *Registry) Start(id string) (string, context.Context, func()) {
+	seq := r.seq.Add(1)
+	if id == "" {
+		id = fmt.Sprintf("op-%d", seq)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if prev, ok := r.ops[id]; ok {
+		prev.cancel()
+	}
+	ctx, cancel := context.WithCancel(r.base)
+	r.ops[id] = operation{cancel: cancel, seq: seq}
+
+	done := func() {
+		cancel()
+		r.mu.Lock()
+		if op, ok := r.ops[id]; ok && op.seq == seq {
+			delete(r.ops, id)
+		}
+		r.mu.Unlock()
+	}
+	return id, ctx, done
+}
+
+func (r *Registry) Cancel(id string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	op, ok := r.ops[id]
+	if ok {
+		op.cancel()
+		delete(r.ops, id)
+	}
+	return ok
+}
+
+func (r *Registry) CancelAll() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, op := range r.ops {
+		op.cancel()
+		delete(r.ops, id)
+	}
+	r.cancel()
+}
+
+func (r *Registry) Running() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ids := make([]string, 0, len(r.ops))
+	for id := range r.ops {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// Run executes fn as operation id and reports ErrCancelled if the
+// operation was cancelled before fn finished.
+func Run[T any](r *Registry, id string, fn func(ctx context.Context) (T, error)) (T, error) {
+	_, ctx, done := r.Start(id)
+	defer done()
+
+	result, err := fn(ctx)
+	if ctx.Err() != nil {
+		var zero T
+		return zero, ErrCancelled
+	}
+	return result, err
+}
+
+// Do is Run for operations without a result.
+func Do(r *Registry, id string, fn func(ctx context.Context) error) error {
+	_, err := Run(r, id, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, fn(ctx)
+	})
+	return err
+}
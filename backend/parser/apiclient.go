@@ -0,0 +1,70 @@
+package parser
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/lanxre/mc-launcher/backend/nettools"
+)
+
+const apiUserAgent = "lanxre/mc-launcher (github.com/lanxre/mc-launcher)"
+
+func newAPIClient() *http.Client {
+	return &http.Client{
+		Timeout:   30 * time.Second,
+		Transport: nettools.Transport(),
+	}
+}
+
+func getJSON(ctx context.Context, client *http.Client, url string, headers map[string]string, out any) error {
+	return doJSON(ctx, client, "GET", url, headers, nil, out)
+}
+
+func postJSON(ctx context.Context, client *http.Client, url string, headers map[string]string, body, out any) error {
+	return doJSON(ctx, client, "POST", url, headers, body, out)
+}
+
+func doJSON(ctx context.Context, client *http.Client, method, url string, headers map[string]string, body, out any) error {
+	var reader io.Reader
+	if body != nil {
+		raw, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request body: %w", err)
+		}
+		reader = bytes.NewReader(raw)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("User-Agent", apiUserAgent)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return &ScrapeError{URL: url, Status: resp.StatusCode, Err: classifyResponse(resp.StatusCode, msg, nil)}
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response from %s: %w", url, err)
+	}
+	return nil
+}
@@ -0,0 +1,205 @@
+package parser
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/gocolly/colly/v2"
+)
+
+// Category is one of the minecraft-inside catalogues. Its value is the
+// site path the catalogue lives under.
+type Category string
+
+const (
+	CategoryMods          Category = "mods"
+	CategoryResourcePacks Category = "resourcepacks"
+	CategoryShaders       Category = "shaders"
+	CategoryMaps          Category = "maps"
+	CategoryModpacks      Category = "modpacks"
+)
+
+var resolutionPattern = regexp.MustCompile(`(?i)\b(\d{2,4})\s*x\b`)
+
+// InstallDir is the directory inside .minecraft that files of the
+// category are installed into. Modpacks have none, see Installable.
+func (c Category) InstallDir() string {
+	switch c {
+	case CategoryResourcePacks:
+		return "resourcepacks"
+	case CategoryShaders:
+		return "shaderpacks"
+	case CategoryMaps:
+		return "saves"
+	default:
+		return "mods"
+	}
+}
+
+// Installable reports whether files of the category can be installed
+// directly. A modpack is a whole game instance and needs a launcher that
+// imports it, so its files are only offered for download.
+func (c Category) Installable() bool {
+	return c != CategoryModpacks
+}
+
+// Extract reports whether downloaded archives of the category must be
+// unpacked rather than copied, as worlds are directories.
+func (c Category) Extract() bool {
+	return c == CategoryMaps
+}
+
+func ParseCategory(name string) (Category, error) {
+	switch c := Category(name); c {
+	case CategoryMods, CategoryResourcePacks, CategoryShaders, CategoryMaps, CategoryModpacks:
+		return c, nil
+	}
+	return "", fmt.Errorf("unknown category %q", name)
+}
+
+// CatalogueEntry holds what every catalogue post has in common.
+type CatalogueEntry struct {
+	Source      string    `yaml:"source"`
+	Category    Category  `yaml:"category"`
+	Name        string    `yaml:"name"`
+	Icon        string    `yaml:"icon"`
+	PageLink    string    `yaml:"page_link"`
+	Description string    `yaml:"description"`
+	Versions    []string  `yaml:"versions"`
+	Tags        []string  `yaml:"tags"`
+	Screenshots []string  `yaml:"screenshots"`
+	Details     []ModFile `yaml:"details"`
+}
+
+type ResourcePack struct {
+	CatalogueEntry `yaml:",inline"`
+	Resolution     string `yaml:"resolution"`
+}
+
+// ShaderPack lists the shader loaders (OptiFine, Iris) the pack runs on.
+type ShaderPack struct {
+	CatalogueEntry `yaml:",inline"`
+	Loaders        []string `yaml:"loaders"`
+}
+
+type MinecraftMap struct {
+	CatalogueEntry `yaml:",inline"`
+	Genres         []string `yaml:"genres"`
+}
+
+type Modpack struct {
+	CatalogueEntry `yaml:",inline"`
+	Loaders        []string `yaml:"loaders"`
+}
+
+type ResourcePacksPage struct {
+	Items      []ResourcePack `yaml:"items"`
+	Pagination `yaml:",inline"`
+	Freshness  `yaml:",inline"`
+}
+
+type ShaderPacksPage struct {
+	Items      []ShaderPack `yaml:"items"`
+	Pagination `yaml:",inline"`
+	Freshness  `yaml:",inline"`
+}
+
+type MapsPage struct {
+	Items      []MinecraftMap `yaml:"items"`
+	Pagination `yaml:",inline"`
+	Freshness  `yaml:",inline"`
+}
+
+type ModpacksPage struct {
+	Items      []Modpack `yaml:"items"`
+	Pagination `yaml:",inline"`
+	Freshness  `yaml:",inline"`
+}
+
+func ScrapeCatalogueList(ctx context.Context, category Category, url string, page int) ([]CatalogueEntry, Pagination, error) {
+	log.Printf("🔍 Scraping %s list: %s", category, url)
+
+	c := newCollector(ctx)
+	errs := setupErrorHandler(c)
+	setupLayoutCheck(c, errs, "div.box")
+	pagination := setupPaginationHandler(c, page)
+
+	var entries []CatalogueEntry
+	c.OnHTML("div.box.box_grass.post", func(e *colly.HTMLElement) {
+		entry := parseCatalogueBlock(e, category)
+		if entry.Name != "" {
+			entries = append(entries, entry)
+		}
+	})
+
+	if err := c.Visit(url); err != nil {
+		return nil, Pagination{}, fmt.Errorf("failed to visit page: %w", err)
+	}
+	c.Wait()
+
+	return entries, pagination(), errs.err()
+}
+
+// ScrapeCatalogueDetails fills the screenshots and files of entry from its
+// page. Files not matching versions are skipped unless versions is empty.
+func ScrapeCatalogueDetails(ctx context.Context, entry *CatalogueEntry, versions []string) error {
+	c := newCollector(ctx)
+	errs := setupErrorHandler(c)
+	screenshots := setupScreenshotHandler(c)
+	details := setupDetailsHandler(c, versions)
+	setupLayoutCheck(c, errs, "div.box__body")
+
+	c.OnHTML("h1", func(e *colly.HTMLElement) {
+		if entry.Name == "" {
+			name, pageVersions := nameParser(strings.TrimSpace(e.Text), "[")
+			entry.Name = name
+			entry.Versions = nameVersionParse(pageVersions)
+		}
+	})
+
+	if err := c.Visit(entry.PageLink); err != nil {
+		return fmt.Errorf("failed to visit page: %w", err)
+	}
+	c.Wait()
+
+	entry.Screenshots = processScreenshots(screenshots())
+	entry.Details = details()
+	return errs.err()
+}
+
+func parseCatalogueBlock(e *colly.HTMLElement, category Category) CatalogueEntry {
+	name, versions := nameParser(strings.TrimSpace(e.ChildText("h2.box__title a")), "[")
+	return CatalogueEntry{
+		Source:      MinecraftInsideID,
+		Category:    category,
+		Name:        name,
+		Icon:        e.Request.AbsoluteURL(e.ChildAttr("a.post__cover img", "src")),
+		PageLink:    e.Request.AbsoluteURL(e.ChildAttr("h2.box__title a", "href")),
+		Description: cleanDescription(e.ChildText("div.box__body > div:first-child"), name),
+		Versions:    nameVersionParse(versions),
+		Tags:        e.ChildAttrs("i.icon", "title"),
+	}
+}
+
+func toResourcePack(entry CatalogueEntry) ResourcePack {
+	var resolution string
+	if m := resolutionPattern.FindStringSubmatch(entry.Name); m != nil {
+		resolution = m[1] + "x"
+	}
+	return ResourcePack{CatalogueEntry: entry, Resolution: resolution}
+}
+
+func toShaderPack(entry CatalogueEntry) ShaderPack {
+	return ShaderPack{CatalogueEntry: entry, Loaders: entry.Tags}
+}
+
+func toMinecraftMap(entry CatalogueEntry) MinecraftMap {
+	return MinecraftMap{CatalogueEntry: entry, Genres: entry.Tags}
+}
+
+func toModpack(entry CatalogueEntry) Modpack {
+	return Modpack{CatalogueEntry: entry, Loaders: entry.Tags}
+}
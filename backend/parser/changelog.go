@@ -0,0 +1,123 @@
+package parser
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+type ChangelogEntry struct {
+	File      ModFile `yaml:"file"`
+	Changelog string  `yaml:"changelog"`
+}
+
+// Changelog is what changed between an installed file and a candidate.
+// Entries run from the candidate back to the file after the installed one;
+// for a downgrade they run from the installed file back to the one after
+// the candidate.
+type Changelog struct {
+	Installed ModFile          `yaml:"installed"`
+	Candidate ModFile          `yaml:"candidate"`
+	Downgrade bool             `yaml:"downgrade"`
+	Entries   []ChangelogEntry `yaml:"entries"`
+	Markdown  string           `yaml:"markdown"`
+}
+
+// BuildChangelog collects the changelogs of the files of the mod at link
+// released between the installed and the candidate file. Only files for
+// the candidate's loaders and game versions are included, so builds of the
+// same release for other targets are not repeated.
+func BuildChangelog(ctx context.Context, src ModSource, link, installedID, candidateID string) (Changelog, error) {
+	files, err := src.GetFiles(ctx, link)
+	if err != nil {
+		return Changelog{}, err
+	}
+	files = newestFirst(files)
+
+	installed := slices.IndexFunc(files, func(f ModFile) bool { return f.FileID == installedID })
+	if installed == -1 {
+		return Changelog{}, fmt.Errorf("%w: file %s of %s", ErrNotFound, installedID, link)
+	}
+	candidate := slices.IndexFunc(files, func(f ModFile) bool { return f.FileID == candidateID })
+	if candidate == -1 {
+		return Changelog{}, fmt.Errorf("%w: file %s of %s", ErrNotFound, candidateID, link)
+	}
+
+	log := Changelog{
+		Installed: files[installed],
+		Candidate: files[candidate],
+		Downgrade: candidate > installed,
+	}
+
+	newer, older := min(installed, candidate), max(installed, candidate)
+	for i := newer; i < older; i++ {
+		file := files[i]
+		if i != candidate && !sameTarget(file, log.Candidate) {
+			continue
+		}
+
+		if file.Changelog == "" {
+			if cs, ok := src.(ChangelogSource); ok {
+				if file.Changelog, err = cs.FileChangelog(ctx, link, file); err != nil {
+					return log, err
+				}
+			}
+		}
+		log.Entries = append(log.Entries, ChangelogEntry{File: file, Changelog: file.Changelog})
+	}
+
+	log.Markdown = changelogMarkdown(log.Entries)
+	return log, nil
+}
+
+// newestFirst orders files by publish date when every file has one, and
+// otherwise trusts the source, which lists the newest files first.
+func newestFirst(files []ModFile) []ModFile {
+	if slices.ContainsFunc(files, func(f ModFile) bool { return f.Published.IsZero() }) {
+		return files
+	}
+	slices.SortStableFunc(files, func(a, b ModFile) int {
+		return b.Published.Compare(a.Published)
+	})
+	return files
+}
+
+// sameTarget reports whether file is built for a loader and game version
+// of target. Files without loaders count as matching any loader.
+func sameTarget(file, target ModFile) bool {
+	if len(file.Loaders) > 0 && len(target.Loaders) > 0 &&
+		!slices.ContainsFunc(file.Loaders, func(l string) bool { return containsFold(target.Loaders, l) }) {
+		return false
+	}
+	return matchesVersions(target.Versions, file.Versions)
+}
+
+func changelogMarkdown(entries []ChangelogEntry) string {
+	var b strings.Builder
+	for _, e := range entries {
+		b.WriteString("## " + fileTitle(e.File))
+		if !e.File.Published.IsZero() {
+			b.WriteString(" (" + e.File.Published.Format("2006-01-02") + ")")
+		}
+		b.WriteString("\n\n")
+		if e.Changelog != "" {
+			b.WriteString(e.Changelog)
+		} else {
+			b.WriteString("_No changelog._")
+		}
+		b.WriteString("\n\n")
+	}
+	return strings.TrimSpace(b.String())
+}
+
+func fileTitle(f ModFile) string {
+	if f.FileName != "" {
+		return markdownEscaper.Replace(f.FileName)
+	}
+	title := strings.Join(f.Versions, ", ")
+	if len(f.Loaders) > 0 {
+		title += " " + strings.Join(f.Loaders, ", ")
+	}
+	return markdownEscaper.Replace(strings.TrimSpace(title + " #" + f.FileID))
+}
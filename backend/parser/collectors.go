@@ -1,69 +1,33 @@
 package parser
 
 import (
-	"crypto/tls"
+	"context"
 	"log"
-	"net/http"
-	"time"
 
 	"github.com/gocolly/colly/v2"
+	"github.com/lanxre/mc-launcher/backend/nettools"
 )
 
-func newCollectorWithRetry(maxRetries int) *colly.Collector {
+// newCollector returns a collector for minecraft-inside.ru. Pacing, 429
+// retries and backoff are left to the shared nettools transport, so any
+// number of collectors can run at once without bursting the site. Cookies
+// and headers come from the shared session.
+func newCollector(ctx context.Context) *colly.Collector {
 	c := colly.NewCollector(
 		colly.AllowedDomains("minecraft-inside.ru"),
 		colly.Async(true),
+		colly.StdlibContext(ctx),
 	)
-
-	c.OnError(func(r *colly.Response, err error) {
-		if r.StatusCode == 429 {
-			retryAfter := 5 + (r.Request.Ctx.GetAny("retryCount").(int) * 3)
-			log.Printf("⏳ Got 429 Too Many Requests. Retrying %s after %d sec...", r.Request.URL, retryAfter)
-			time.Sleep(time.Duration(retryAfter) * time.Second)
-
-			retryCount := r.Request.Ctx.GetAny("retryCount").(int)
-			if retryCount < maxRetries {
-				r.Request.Ctx.Put("retryCount", retryCount+1)
-				if err := r.Request.Retry(); err != nil {
-					log.Printf("❌ Retry failed for %s: %v", r.Request.URL, err)
-				}
-			} else {
-				log.Printf("❌ Max retries reached for %s", r.Request.URL)
-			}
-		} else {
-			log.Printf("⚠️ Error %d on %s: %v", r.StatusCode, r.Request.URL, err)
-		}
-	})
+	c.WithTransport(nettools.Transport())
+	c.SetCookieJar(nettools.Jar())
 
 	c.OnRequest(func(r *colly.Request) {
-		if _, ok := r.Ctx.GetAny("retryCount").(int); !ok {
-			r.Ctx.Put("retryCount", 0)
-		}
+		nettools.ApplyHeaders(*r.Headers, r.URL)
 	})
 
-	return c
-}
-
-func newCollector() *colly.Collector {
-	c := colly.NewCollector(
-		colly.UserAgent("Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/91.0.4472.124 Safari/537.36"),
-		colly.AllowedDomains("minecraft-inside.ru"),
-		colly.Async(true),
-	)
-	c.WithTransport(&http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: false},
+	c.OnError(func(r *colly.Response, err error) {
+		log.Printf("⚠️ Error %d on %s: %v", r.StatusCode, r.Request.URL, err)
 	})
-	return c
-}
 
-func newDependencyCollector() *colly.Collector {
-	c := colly.NewCollector(
-		colly.Async(true),
-		colly.AllowedDomains("minecraft-inside.ru"),
-	)
-	c.Limit(&colly.LimitRule{
-		DomainGlob:  "*minecraft-inside.*",
-		Parallelism: 3,
-	})
 	return c
 }
@@ -0,0 +1,648 @@
+package parser
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+const (
+	CurseForgeID             = "curseforge"
+	DefaultCurseForgeBaseURL = "https://api.curseforge.com"
+
+	curseForgeGameID     = 432
+	curseForgeModsClass  = 6
+	curseForgePageSize   = 20
+	curseForgeFilesPage  = 50
+	curseForgeRequiredTy = 3
+)
+
+var curseForgeSortFields = map[SortOrder]string{
+	SortRelevance: "2",
+	SortPopular:   "2",
+	SortNewest:    "11",
+	SortUpdated:   "3",
+	SortDownloads: "6",
+}
+
+var curseForgeLoaderTypes = map[string]int{
+	"forge":    1,
+	"fabric":   4,
+	"quilt":    5,
+	"neoforge": 6,
+}
+
+type curseForgeSource struct {
+	baseURL string
+	apiKey  string
+	client  *http.Client
+
+	mu    sync.Mutex
+	slugs map[string]int
+}
+
+// NewCurseForgeSource returns a source backed by the CurseForge Core API.
+// The API key is required by CurseForge for every request.
+func NewCurseForgeSource(baseURL, apiKey string) ModSource {
+	if baseURL == "" {
+		baseURL = DefaultCurseForgeBaseURL
+	}
+	return &curseForgeSource{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		apiKey:  apiKey,
+		client:  newAPIClient(),
+		slugs:   make(map[string]int),
+	}
+}
+
+type curseForgeMod struct {
+	ID            int     `json:"id"`
+	Name          string  `json:"name"`
+	Slug          string  `json:"slug"`
+	Summary       string  `json:"summary"`
+	DownloadCount float64 `json:"downloadCount"`
+	DateModified  string  `json:"dateModified"`
+	Categories    []struct {
+		Slug string `json:"slug"`
+		Name string `json:"name"`
+	} `json:"categories"`
+	Links struct {
+		WebsiteURL string `json:"websiteUrl"`
+		WikiURL    string `json:"wikiUrl"`
+		IssuesURL  string `json:"issuesUrl"`
+		SourceURL  string `json:"sourceUrl"`
+	} `json:"links"`
+	Authors []struct {
+		Name string `json:"name"`
+	} `json:"authors"`
+	DateCreated string `json:"dateCreated"`
+	Logo        struct {
+		URL          string `json:"url"`
+		ThumbnailURL string `json:"thumbnailUrl"`
+	} `json:"logo"`
+	Screenshots []struct {
+		URL string `json:"url"`
+	} `json:"screenshots"`
+	LatestFilesIndexes []struct {
+		GameVersion string `json:"gameVersion"`
+		ModLoader   *int   `json:"modLoader"`
+	} `json:"latestFilesIndexes"`
+}
+
+type curseForgeFile struct {
+	ID            int      `json:"id"`
+	ModID         int      `json:"modId"`
+	DisplayName   string   `json:"displayName"`
+	FileName      string   `json:"fileName"`
+	FileDate      string   `json:"fileDate"`
+	FileLength    int64    `json:"fileLength"`
+	DownloadCount int64    `json:"downloadCount"`
+	DownloadURL   string   `json:"downloadUrl"`
+	GameVersions  []string `json:"gameVersions"`
+	Hashes        []struct {
+		Value string `json:"value"`
+		Algo  int    `json:"algo"`
+	} `json:"hashes"`
+	Dependencies []struct {
+		ModID        int `json:"modId"`
+		RelationType int `json:"relationType"`
+	} `json:"dependencies"`
+	FileFingerprint uint32 `json:"fileFingerprint"`
+}
+
+type curseForgeFingerprintResponse struct {
+	Data struct {
+		ExactMatches []struct {
+			ID   int            `json:"id"`
+			File curseForgeFile `json:"file"`
+		} `json:"exactMatches"`
+	} `json:"data"`
+}
+
+func (s *curseForgeSource) ID() string {
+	return CurseForgeID
+}
+
+func (s *curseForgeSource) Name() string {
+	return "CurseForge"
+}
+
+func (s *curseForgeSource) Search(ctx context.Context, query SearchQuery) (ModsPage, error) {
+	params := s.searchParams()
+	params.Set("searchFilter", query.Text)
+	params.Set("pageSize", strconv.Itoa(curseForgePageSize))
+	params.Set("sortField", curseForgeSortFields[query.Sort])
+	params.Set("sortOrder", "desc")
+
+	serverSide := queryFilter(0)
+	if versions, ok := exactVersions(query.Versions); ok && len(versions) == 1 {
+		params.Set("gameVersion", versions[0])
+		serverSide |= filterVersion
+	}
+	if len(query.Loaders) == 1 {
+		if loaderType, ok := curseForgeLoaderTypes[strings.ToLower(query.Loaders[0])]; ok {
+			params.Set("modLoaderType", strconv.Itoa(loaderType))
+			serverSide |= filterLoader
+		}
+	}
+
+	return scanFiltered(query, serverSide, func(page int) (ModsPage, error) {
+		params.Set("index", strconv.Itoa((page-1)*curseForgePageSize))
+		return s.search(ctx, params, page)
+	})
+}
+
+func (s *curseForgeSource) search(ctx context.Context, params url.Values, page int) (ModsPage, error) {
+	var resp struct {
+		Data       []curseForgeMod `json:"data"`
+		Pagination struct {
+			TotalCount int `json:"totalCount"`
+		} `json:"pagination"`
+	}
+	if err := s.get(ctx, "/v1/mods/search?"+params.Encode(), &resp); err != nil {
+		return ModsPage{}, err
+	}
+
+	mods := make([]MinecraftMod, 0, len(resp.Data))
+	for _, m := range resp.Data {
+		s.rememberSlug(m)
+		mods = append(mods, s.toMod(m))
+	}
+	return ModsPage{Items: mods, Pagination: NewPagination(page, curseForgePageSize, resp.Pagination.TotalCount)}, nil
+}
+
+func (s *curseForgeSource) ListPage(ctx context.Context, page int) (ModsPage, error) {
+	return s.Search(ctx, SearchQuery{Page: page})
+}
+
+func (s *curseForgeSource) GetDetails(ctx context.Context, link string, versions []string) (MinecraftMod, error) {
+	modID, err := s.modID(ctx, link)
+	if err != nil {
+		return MinecraftMod{}, err
+	}
+
+	var resp struct {
+		Data curseForgeMod `json:"data"`
+	}
+	if err := s.get(ctx, fmt.Sprintf("/v1/mods/%d", modID), &resp); err != nil {
+		return MinecraftMod{}, err
+	}
+	mod := s.toMod(resp.Data)
+	if mod.Body, err = s.description(ctx, modID); err != nil {
+		return mod, err
+	}
+
+	files, err := s.files(ctx, modID, versions)
+	if err != nil {
+		return mod, err
+	}
+	for _, f := range files {
+		mod.Details = append(mod.Details, curseForgeModFile(f, mod.ModPageLink))
+	}
+
+	if len(files) > 0 {
+		mod.Dependency, err = s.requiredDependencies(ctx, files[0])
+	}
+	return mod, err
+}
+
+func (s *curseForgeSource) GetFiles(ctx context.Context, link string) ([]ModFile, error) {
+	modID, err := s.modID(ctx, link)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := s.files(ctx, modID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	details := make([]ModFile, 0, len(files))
+	for _, f := range files {
+		details = append(details, curseForgeModFile(f, curseForgeProjectURL(link, modID)))
+	}
+	return details, nil
+}
+
+func (s *curseForgeSource) ResolveDependencies(ctx context.Context, depends []ModDependency, versions []string) ([]ModDependency, error) {
+	for i := range depends {
+		modID, err := s.modID(ctx, depends[i].ModPageLink)
+		if err != nil {
+			return depends, err
+		}
+
+		files, err := s.files(ctx, modID, versions)
+		if err != nil {
+			return depends, err
+		}
+
+		depends[i].Source = s.ID()
+		for _, f := range files {
+			depends[i].Details = append(depends[i].Details, curseForgeModFile(f, curseForgeProjectURL(depends[i].ModPageLink, modID)))
+		}
+
+		if len(files) > 0 {
+			subDeps, err := s.requiredDependencies(ctx, files[0])
+			if err != nil {
+				return depends, err
+			}
+			depends[i].Dependency = append(depends[i].Dependency, subDeps...)
+		}
+	}
+	return depends, nil
+}
+
+// MatchFile identifies a jar through the CurseForge fingerprint endpoint.
+func (s *curseForgeSource) MatchFile(ctx context.Context, data []byte) (MinecraftMod, error) {
+	matches, err := s.MatchFingerprints(ctx, []uint32{CurseForgeFingerprint(data)})
+	if err != nil {
+		return MinecraftMod{}, err
+	}
+	if len(matches) == 0 {
+		return MinecraftMod{}, fmt.Errorf("no CurseForge file matches this fingerprint: %w", ErrNotFound)
+	}
+	return matches[0], nil
+}
+
+// MatchFingerprints returns the mods owning files with the given
+// fingerprints, each carrying the matched file in Details.
+func (s *curseForgeSource) MatchFingerprints(ctx context.Context, fingerprints []uint32) ([]MinecraftMod, error) {
+	var resp curseForgeFingerprintResponse
+	body := map[string][]uint32{"fingerprints": fingerprints}
+	path := fmt.Sprintf("/v1/fingerprints/%d", curseForgeGameID)
+	if err := s.post(ctx, path, body, &resp); err != nil {
+		return nil, err
+	}
+
+	ids := make([]int, 0, len(resp.Data.ExactMatches))
+	for _, m := range resp.Data.ExactMatches {
+		ids = append(ids, m.ID)
+	}
+	mods, err := s.mods(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[int]curseForgeMod, len(mods))
+	for _, m := range mods {
+		byID[m.ID] = m
+	}
+
+	result := make([]MinecraftMod, 0, len(resp.Data.ExactMatches))
+	for _, m := range resp.Data.ExactMatches {
+		mod := s.toMod(byID[m.ID])
+		mod.Details = []ModFile{curseForgeModFile(m.File, mod.ModPageLink)}
+		result = append(result, mod)
+	}
+	return result, nil
+}
+
+// files returns every file of the mod built for one of versions, paging
+// through the whole list. A single exact version is filtered server-side.
+func (s *curseForgeSource) files(ctx context.Context, modID int, versions []string) ([]curseForgeFile, error) {
+	params := url.Values{}
+	params.Set("pageSize", strconv.Itoa(curseForgeFilesPage))
+	if exact, ok := exactVersions(versions); ok && len(exact) == 1 {
+		params.Set("gameVersion", exact[0])
+	}
+
+	var all []curseForgeFile
+	for index := 0; ; {
+		var resp struct {
+			Data       []curseForgeFile `json:"data"`
+			Pagination struct {
+				ResultCount int `json:"resultCount"`
+				TotalCount  int `json:"totalCount"`
+			} `json:"pagination"`
+		}
+		params.Set("index", strconv.Itoa(index))
+		if err := s.get(ctx, fmt.Sprintf("/v1/mods/%d/files?%s", modID, params.Encode()), &resp); err != nil {
+			return nil, err
+		}
+		all = append(all, resp.Data...)
+
+		index += resp.Pagination.ResultCount
+		if resp.Pagination.ResultCount == 0 || index >= resp.Pagination.TotalCount {
+			break
+		}
+	}
+
+	if len(versions) == 0 {
+		return all, nil
+	}
+
+	var matched []curseForgeFile
+	for _, f := range all {
+		if matchesVersions(versions, f.GameVersions) {
+			matched = append(matched, f)
+		}
+	}
+	return matched, nil
+}
+
+func (s *curseForgeSource) mods(ctx context.Context, ids []int) ([]curseForgeMod, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	var resp struct {
+		Data []curseForgeMod `json:"data"`
+	}
+	body := map[string][]int{"modIds": ids}
+	if err := postJSON(ctx, s.client, s.baseURL+"/v1/mods", s.headers(), body, &resp); err != nil {
+		return nil, err
+	}
+	for _, m := range resp.Data {
+		s.rememberSlug(m)
+	}
+	return resp.Data, nil
+}
+
+func (s *curseForgeSource) requiredDependencies(ctx context.Context, f curseForgeFile) ([]ModDependency, error) {
+	var ids []int
+	for _, dep := range f.Dependencies {
+		if dep.RelationType == curseForgeRequiredTy && !slices.Contains(ids, dep.ModID) {
+			ids = append(ids, dep.ModID)
+		}
+	}
+
+	mods, err := s.mods(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	depends := make([]ModDependency, 0, len(mods))
+	for _, m := range mods {
+		depends = append(depends, ModDependency{
+			Source:      s.ID(),
+			ModPageLink: curseForgePageLink(m),
+			Name:        m.Name,
+		})
+	}
+	return depends, nil
+}
+
+// modID accepts a numeric mod ID or a curseforge.com page link, resolving
+// the slug through the search endpoint the first time it is seen.
+func (s *curseForgeSource) modID(ctx context.Context, link string) (int, error) {
+	slug := strings.TrimRight(link, "/")
+	if idx := strings.LastIndexByte(slug, '/'); idx != -1 {
+		slug = slug[idx+1:]
+	}
+	if id, err := strconv.Atoi(slug); err == nil {
+		return id, nil
+	}
+
+	s.mu.Lock()
+	id, ok := s.slugs[slug]
+	s.mu.Unlock()
+	if ok {
+		return id, nil
+	}
+
+	params := s.searchParams()
+	params.Set("slug", slug)
+
+	var resp struct {
+		Data []curseForgeMod `json:"data"`
+	}
+	if err := s.get(ctx, "/v1/mods/search?"+params.Encode(), &resp); err != nil {
+		return 0, err
+	}
+	if len(resp.Data) == 0 {
+		return 0, fmt.Errorf("curseforge mod %q not found", slug)
+	}
+	s.rememberSlug(resp.Data[0])
+	return resp.Data[0].ID, nil
+}
+
+func (s *curseForgeSource) rememberSlug(m curseForgeMod) {
+	s.mu.Lock()
+	s.slugs[m.Slug] = m.ID
+	s.mu.Unlock()
+}
+
+func (s *curseForgeSource) searchParams() url.Values {
+	params := url.Values{}
+	params.Set("gameId", strconv.Itoa(curseForgeGameID))
+	params.Set("classId", strconv.Itoa(curseForgeModsClass))
+	return params
+}
+
+func (s *curseForgeSource) toMod(m curseForgeMod) MinecraftMod {
+	var versions, loaders []string
+	for _, idx := range m.LatestFilesIndexes {
+		if !slices.Contains(versions, idx.GameVersion) {
+			versions = append(versions, idx.GameVersion)
+		}
+		if idx.ModLoader == nil {
+			continue
+		}
+		for name, ty := range curseForgeLoaderTypes {
+			if ty == *idx.ModLoader && !slices.Contains(loaders, name) {
+				loaders = append(loaders, name)
+			}
+		}
+	}
+
+	screenshots := make([]string, 0, len(m.Screenshots))
+	for _, img := range m.Screenshots {
+		screenshots = append(screenshots, img.URL)
+	}
+
+	var author string
+	if len(m.Authors) > 0 {
+		author = m.Authors[0].Name
+	}
+
+	categories := make([]string, 0, len(m.Categories))
+	for _, c := range m.Categories {
+		categories = append(categories, c.Slug)
+	}
+
+	return MinecraftMod{
+		Source:      s.ID(),
+		Name:        m.Name,
+		Icon:        m.Logo.ThumbnailURL,
+		ModPageLink: curseForgePageLink(m),
+		Description: m.Summary,
+		Versions:    versions,
+		Screenshots: screenshots,
+		Loaders:     loaders,
+		Categories:  categories,
+		Downloads:   int64(m.DownloadCount),
+		Updated:     parseDate(m.DateModified),
+		Author:      author,
+		Published:   parseDate(m.DateCreated),
+		Links: ModLinks{
+			Source: m.Links.SourceURL,
+			Issues: m.Links.IssuesURL,
+			Wiki:   m.Links.WikiURL,
+		},
+	}
+}
+
+// description returns the mod description, which the API serves as HTML,
+// converted to Markdown.
+func (s *curseForgeSource) description(ctx context.Context, modID int) (string, error) {
+	var resp struct {
+		Data string `json:"data"`
+	}
+	if err := s.get(ctx, fmt.Sprintf("/v1/mods/%d/description", modID), &resp); err != nil {
+		return "", err
+	}
+	return htmlFragmentToMarkdown(resp.Data), nil
+}
+
+// FileChangelog fetches the changelog of one file, which the files list
+// does not include.
+func (s *curseForgeSource) FileChangelog(ctx context.Context, link string, file ModFile) (string, error) {
+	modID, err := s.modID(ctx, link)
+	if err != nil {
+		return "", err
+	}
+	var resp struct {
+		Data string `json:"data"`
+	}
+	if err := s.get(ctx, fmt.Sprintf("/v1/mods/%d/files/%s/changelog", modID, url.PathEscape(file.FileID)), &resp); err != nil {
+		return "", err
+	}
+	return htmlFragmentToMarkdown(resp.Data), nil
+}
+
+func (s *curseForgeSource) headers() map[string]string {
+	return map[string]string{"x-api-key": s.apiKey}
+}
+
+func (s *curseForgeSource) get(ctx context.Context, path string, out any) error {
+	if s.apiKey == "" {
+		return fmt.Errorf("curseforge: %w", ErrNotConfigured)
+	}
+	return getJSON(ctx, s.client, s.baseURL+path, s.headers(), out)
+}
+
+func (s *curseForgeSource) post(ctx context.Context, path string, body, out any) error {
+	if s.apiKey == "" {
+		return fmt.Errorf("curseforge: %w", ErrNotConfigured)
+	}
+	return postJSON(ctx, s.client, s.baseURL+path, s.headers(), body, out)
+}
+
+func curseForgePageLink(m curseForgeMod) string {
+	if m.Links.WebsiteURL != "" {
+		return m.Links.WebsiteURL
+	}
+	return fmt.Sprintf("https://www.curseforge.com/minecraft/mc-mods/%s", m.Slug)
+}
+
+// curseForgeProjectURL returns link if it is a page link, else the page
+// CurseForge redirects project IDs to.
+func curseForgeProjectURL(link string, modID int) string {
+	if strings.HasPrefix(link, "http") {
+		return strings.TrimRight(link, "/")
+	}
+	return fmt.Sprintf("https://www.curseforge.com/projects/%d", modID)
+}
+
+// curseForgeHashAlgos names the hash algorithm IDs of the files API.
+var curseForgeHashAlgos = map[int]string{1: "sha1", 2: "md5"}
+
+// curseForgeModFile converts a file of the mod at pageLink. Files whose
+// author disabled third-party distribution have no downloadUrl and are
+// left to be downloaded by hand from their page.
+func curseForgeModFile(f curseForgeFile, pageLink string) ModFile {
+	versions, loaders := splitCurseForgeVersions(f.GameVersions)
+	file := ModFile{
+		Source:        CurseForgeID,
+		FileID:        strconv.Itoa(f.ID),
+		FileName:      f.FileName,
+		Versions:      versions,
+		Loaders:       loaders,
+		URL:           f.DownloadURL,
+		Size:          strconv.FormatInt(f.FileLength, 10),
+		SizeBytes:     f.FileLength,
+		Date:          f.FileDate,
+		Published:     parseDate(f.FileDate),
+		Downloads:     strconv.FormatInt(f.DownloadCount, 10),
+		DownloadCount: f.DownloadCount,
+	}
+	if f.DownloadURL == "" {
+		file.ManualURL = fmt.Sprintf("%s/files/%d", strings.TrimRight(pageLink, "/"), f.ID)
+	}
+	for _, h := range f.Hashes {
+		if algo, ok := curseForgeHashAlgos[h.Algo]; ok {
+			if file.Hashes == nil {
+				file.Hashes = make(map[string]string)
+			}
+			file.Hashes[algo] = h.Value
+		}
+	}
+	return file
+}
+
+// splitCurseForgeVersions separates Minecraft versions from the loader
+// names CurseForge mixes into gameVersions.
+func splitCurseForgeVersions(gameVersions []string) (versions, loaders []string) {
+	for _, v := range gameVersions {
+		lower := strings.ToLower(v)
+		if _, ok := curseForgeLoaderTypes[lower]; ok {
+			loaders = append(loaders, lower)
+		} else if v != "" && unicode.IsDigit(rune(v[0])) {
+			versions = append(versions, v)
+		}
+	}
+	return versions, loaders
+}
+
+// CurseForgeFingerprint computes the MurmurHash2 fingerprint CurseForge
+// uses for files, which ignores whitespace bytes.
+func CurseForgeFingerprint(data []byte) uint32 {
+	filtered := make([]byte, 0, len(data))
+	for _, b := range data {
+		if b != 9 && b != 10 && b != 13 && b != 32 {
+			filtered = append(filtered, b)
+		}
+	}
+	return murmur2(filtered, 1)
+}
+
+func murmur2(data []byte, seed uint32) uint32 {
+	const m = 0x5bd1e995
+	const r = 24
+
+	length := len(data)
+	h := seed ^ uint32(length)
+
+	for len(data) >= 4 {
+		k := uint32(data[0]) | uint32(data[1])<<8 | uint32(data[2])<<16 | uint32(data[3])<<24
+		k *= m
+		k ^= k >> r
+		k *= m
+		h *= m
+		h ^= k
+		data = data[4:]
+	}
+
+	switch len(data) {
+	case 3:
+		h ^= uint32(data[2]) << 16
+		fallthrough
+	case 2:
+		h ^= uint32(data[1]) << 8
+		fallthrough
+	case 1:
+		h ^= uint32(data[0])
+		h *= m
+	}
+
+	h ^= h >> 13
+	h *= m
+	h ^= h >> 15
+	return h
+}
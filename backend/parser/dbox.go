@@ -0,0 +1,262 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// dboxFile is one entry of the files list a mod page embeds in its
+// dbox_data script variable.
+type dboxFile struct {
+	ID        jsString  `json:"id"`
+	Name      jsString  `json:"name"`
+	Size      jsString  `json:"size"`
+	Created   jsString  `json:"created"`
+	Downloads jsString  `json:"downloads"`
+	Loaders   jsStrings `json:"loaders"`
+	Changelog jsString  `json:"changelog"`
+}
+
+// jsString accepts a string, number or boolean, as the site is not
+// consistent about which one it prints.
+type jsString string
+
+func (s *jsString) UnmarshalJSON(data []byte) error {
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	switch v := v.(type) {
+	case string:
+		*s = jsString(v)
+	case float64, bool:
+		*s = jsString(strings.TrimSpace(string(data)))
+	case nil:
+		*s = ""
+	default:
+		return fmt.Errorf("unexpected value %s", data)
+	}
+	return nil
+}
+
+// jsStrings accepts a list of strings or a single string.
+type jsStrings []string
+
+func (s *jsStrings) UnmarshalJSON(data []byte) error {
+	var one jsString
+	if err := one.UnmarshalJSON(data); err == nil {
+		*s = nil
+		if one != "" {
+			*s = jsStrings{string(one)}
+		}
+		return nil
+	}
+
+	var many []jsString
+	if err := json.Unmarshal(data, &many); err != nil {
+		return err
+	}
+	*s = make(jsStrings, 0, len(many))
+	for _, v := range many {
+		if v != "" {
+			*s = append(*s, string(v))
+		}
+	}
+	return nil
+}
+
+const dboxMarker = "var dbox_data ="
+
+var dboxFilesKey = regexp.MustCompile(`\bfiles["']?\s*:\s*`)
+
+// decodeDboxFiles reads the files list out of a script holding dbox_data.
+// Only that list is decoded, so the rest of the object may hold anything;
+// entries that do not decode are skipped. A missing or unreadable list is
+// reported as ErrLayoutChanged.
+func decodeDboxFiles(script string) ([]dboxFile, error) {
+	start := strings.Index(script, dboxMarker)
+	if start == -1 {
+		return nil, fmt.Errorf("%w: dbox_data not found", ErrLayoutChanged)
+	}
+	rest := script[start+len(dboxMarker):]
+
+	key := dboxFilesKey.FindStringIndex(rest)
+	if key == nil || nextToken(rest, key[1]) != '[' {
+		return nil, fmt.Errorf("%w: dbox_data has no files list", ErrLayoutChanged)
+	}
+	literal, err := jsLiteralAt(rest, key[1])
+	if err != nil {
+		return nil, fmt.Errorf("%w: dbox_data: %v", ErrLayoutChanged, err)
+	}
+
+	var entries []json.RawMessage
+	if err := json.Unmarshal(jsToJSON(literal), &entries); err != nil {
+		return nil, fmt.Errorf("%w: dbox_data: %v", ErrLayoutChanged, err)
+	}
+
+	files := make([]dboxFile, 0, len(entries))
+	for _, raw := range entries {
+		var f dboxFile
+		if err := json.Unmarshal(raw, &f); err == nil && f.ID != "" {
+			files = append(files, f)
+		}
+	}
+	if len(files) == 0 && len(entries) > 0 {
+		return nil, fmt.Errorf("%w: dbox_data files are unreadable", ErrLayoutChanged)
+	}
+	return files, nil
+}
+
+// jsLiteralAt returns the object or array literal that starts at the first
+// bracket at or after start, matching brackets outside strings and
+// comments.
+func jsLiteralAt(src string, start int) (string, error) {
+	open := strings.IndexAny(src[start:], "{[")
+	if open == -1 {
+		return "", errors.New("no object literal")
+	}
+	open += start
+
+	depth := 0
+	for i := open; i < len(src); i++ {
+		switch c := src[i]; {
+		case c == '"' || c == '\'' || c == '`':
+			i = jsStringEnd(src, i)
+		case c == '/' && i+1 < len(src) && (src[i+1] == '/' || src[i+1] == '*'):
+			i = jsCommentEnd(src, i)
+		case c == '{' || c == '[':
+			depth++
+		case c == '}' || c == ']':
+			depth--
+			if depth == 0 {
+				return src[open : i+1], nil
+			}
+		}
+	}
+	return "", errors.New("unterminated object literal")
+}
+
+// jsToJSON rewrites a JavaScript object literal as JSON: it quotes keys,
+// turns single-quoted and template strings into JSON strings, drops
+// comments and trailing commas, and maps undefined to null.
+func jsToJSON(src string) []byte {
+	var b bytes.Buffer
+	for i := 0; i < len(src); i++ {
+		c := src[i]
+		switch {
+		case c == '"' || c == '\'' || c == '`':
+			i = writeJSString(&b, src, i)
+		case c == '/' && i+1 < len(src) && (src[i+1] == '/' || src[i+1] == '*'):
+			i = jsCommentEnd(src, i)
+		case c == ',':
+			if next := nextToken(src, i+1); next != '}' && next != ']' {
+				b.WriteByte(c)
+			}
+		case isIdentStart(c):
+			end := i
+			for end < len(src) && isIdentPart(src[end]) {
+				end++
+			}
+			word := src[i:end]
+			switch {
+			case nextToken(src, end) == ':':
+				b.WriteString(`"` + word + `"`)
+			case word == "undefined":
+				b.WriteString("null")
+			default:
+				b.WriteString(word)
+			}
+			i = end - 1
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.Bytes()
+}
+
+// writeJSString writes the string literal opening at src[start] as a JSON
+// string and returns the index of its closing quote.
+func writeJSString(b *bytes.Buffer, src string, start int) int {
+	quote := src[start]
+	b.WriteByte('"')
+	i := start + 1
+	for ; i < len(src) && src[i] != quote; i++ {
+		c := src[i]
+		switch {
+		case c == '\\' && i+1 < len(src):
+			i++
+			switch e := src[i]; e {
+			case '"', '\\', '/', 'b', 'f', 'n', 'r', 't', 'u':
+				b.WriteByte('\\')
+				b.WriteByte(e)
+			case 'x':
+				b.WriteString(`\u00`)
+			case '\n':
+			default:
+				b.WriteByte(e)
+			}
+		case c == '"':
+			b.WriteString(`\"`)
+		case c < 0x20:
+			fmt.Fprintf(b, `\u%04x`, c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	b.WriteByte('"')
+	return i
+}
+
+// jsStringEnd returns the index of the quote closing the string literal
+// that opens at src[start].
+func jsStringEnd(src string, start int) int {
+	quote := src[start]
+	for i := start + 1; i < len(src); i++ {
+		switch src[i] {
+		case '\\':
+			i++
+		case quote:
+			return i
+		}
+	}
+	return len(src) - 1
+}
+
+// jsCommentEnd returns the index of the last byte of the comment that
+// opens at src[start].
+func jsCommentEnd(src string, start int) int {
+	if src[start+1] == '/' {
+		if end := strings.IndexByte(src[start:], '\n'); end != -1 {
+			return start + end
+		}
+		return len(src) - 1
+	}
+	if end := strings.Index(src[start+2:], "*/"); end != -1 {
+		return start + 2 + end + 1
+	}
+	return len(src) - 1
+}
+
+// nextToken returns the first non-space byte at or after i, or 0.
+func nextToken(src string, i int) byte {
+	for ; i < len(src); i++ {
+		switch src[i] {
+		case ' ', '\t', '\n', '\r':
+		default:
+			return src[i]
+		}
+	}
+	return 0
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || c == '$' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isIdentPart(c byte) bool {
+	return isIdentStart(c) || c >= '0' && c <= '9'
+}
@@ -0,0 +1,90 @@
+package parser
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestDecodeDboxFiles(t *testing.T) {
+	tests := []struct {
+		name   string
+		script string
+		want   []dboxFile
+	}{
+		{
+			name:   "json",
+			script: `var dbox_data = {"files": [{"id": "1", "name": "Для 1.20.1", "loaders": ["forge"]}]};`,
+			want:   []dboxFile{{ID: "1", Name: "Для 1.20.1", Loaders: jsStrings{"forge"}}},
+		},
+		{
+			name: "javascript literal",
+			script: `var dbox_data = {
+				files: [
+					{id: 2, name: 'It\'s 1.19', size: ` + "`1 МБ`" + `, downloads: 10, loaders: 'fabric', changelog: undefined,}, // trailing comma
+					/* second file */ {id: '3', name: "a \"quoted\" name", loaders: [], created: null},
+				],
+			};`,
+			want: []dboxFile{
+				{ID: "2", Name: "It's 1.19", Size: "1 МБ", Downloads: "10", Loaders: jsStrings{"fabric"}},
+				{ID: "3", Name: `a "quoted" name`, Loaders: jsStrings{}},
+			},
+		},
+		{
+			name:   "brackets inside strings",
+			script: `var dbox_data = {files: [{id: 4, name: "[1.20] {beta}", changelog: '<p>]}</p>'}], other: "]"};`,
+			want:   []dboxFile{{ID: "4", Name: "[1.20] {beta}", Changelog: "<p>]}</p>"}},
+		},
+		{
+			name:   "unreadable entries are skipped",
+			script: `var dbox_data = {files: [{id: 5, name: {nested: 1}}, {name: "no id"}, {id: 6, name: "ok"}]};`,
+			want:   []dboxFile{{ID: "6", Name: "ok"}},
+		},
+		{
+			name:   "empty list",
+			script: `var dbox_data = {files: []};`,
+			want:   []dboxFile{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeDboxFiles(tt.script)
+			if err != nil {
+				t.Fatalf("decodeDboxFiles: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got  %+v\nwant %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeDboxFilesLayoutChanged(t *testing.T) {
+	scripts := map[string]string{
+		"no marker":       `var other = {files: []};`,
+		"no files key":    `var dbox_data = {items: [{id: 1}]};`,
+		"files not list":  `var dbox_data = {files: {id: 1}};`,
+		"unterminated":    `var dbox_data = {files: [{id: 1, name: "x"}`,
+		"all unreadable":  `var dbox_data = {files: [{id: 1, name: {}}, {id: 2, loaders: {}}]};`,
+		"not an object":   `var dbox_data = {files: [1, 2, 3]};`,
+		"broken literal":  `var dbox_data = {files: [{id: 1 name: "x"}]};`,
+		"unclosed string": `var dbox_data = {files: [{id: 1, name: "x}]};`,
+	}
+	for name, script := range scripts {
+		t.Run(name, func(t *testing.T) {
+			if _, err := decodeDboxFiles(script); !errors.Is(err, ErrLayoutChanged) {
+				t.Errorf("err = %v, want ErrLayoutChanged", err)
+			}
+		})
+	}
+}
+
+// TestDecodeDboxFilesTruncated feeds every prefix of a valid script to the
+// decoder, which must fail cleanly rather than panic.
+func TestDecodeDboxFilesTruncated(t *testing.T) {
+	script := `var dbox_data = {files: [{id: 1, name: 'Для 1.20.1', loaders: ["forge", 'fabric'], changelog: "<p>a\\nb</p>", // note
+		}, /* c */ {id: "2", size: ` + "`1,5 МБ`" + `}]};`
+	for i := range len(script) {
+		decodeDboxFiles(script[:i])
+	}
+}
@@ -1,31 +1,42 @@
 package parser
 
-const COUNT_RETRY = 3
-
-func ScrapDetails(link string, versions []string) (MinecraftMod, error) {
-	var mod MinecraftMod
-
-	c := newCollector()
+import (
+	"context"
+	"fmt"
+)
+
+func ScrapDetails(ctx context.Context, link string, versions []string) (MinecraftMod, error) {
+	c := newCollector(ctx)
+	errs := setupErrorHandler(c)
+	info := setupModInfoHandler(c)
 	screenshots := setupScreenshotHandler(c)
 	details := setupDetailsHandler(c, versions)
 	dependencies := setupDependenciesHandler(c)
+	setupLayoutCheck(c, errs, "div.box__body")
 
-	c.Visit(link)
+	if err := c.Visit(link); err != nil {
+		return MinecraftMod{}, fmt.Errorf("failed to visit mod page: %w", err)
+	}
 	c.Wait()
 
+	mod := info()
+	mod.ModPageLink = link
 	mod.Screenshots = processScreenshots(screenshots())
 	mod.Details = details()
 	mod.Dependency = dependencies()
-	return mod, nil
+	return mod, errs.err()
 }
 
-func ScrapeMinecraftModDetails(modUrl string) []MinecraftModDetails {
-	c := newCollectorWithRetry(COUNT_RETRY)
+func ScrapeMinecraftModDetails(ctx context.Context, modUrl string) ([]ModFile, error) {
+	c := newCollector(ctx)
+	errs := setupErrorHandler(c)
 
-	minecraftModDeatails := setupMinecraftModDetails(c)
+	minecraftModDeatails := setupMinecraftModDetails(c, errs)
 
-	c.Visit(modUrl)
+	if err := c.Visit(modUrl); err != nil {
+		return nil, fmt.Errorf("failed to visit mod page: %w", err)
+	}
 	c.Wait()
 
-	return minecraftModDeatails()
-}
\ No newline at end of file
+	return minecraftModDeatails(), errs.err()
+}
@@ -0,0 +1,117 @@
+package parser
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/gocolly/colly/v2"
+	"github.com/lanxre/mc-launcher/backend/nettools"
+)
+
+// Sentinel errors returned by the scrapers. Their messages start with a
+// stable code so the frontend can tell them apart from the rejected promise.
+var (
+	ErrRateLimited    = errors.New("rate_limited: too many requests, retries exhausted")
+	ErrCircuitOpen    = nettools.ErrCircuitOpen
+	ErrChallenge      = errors.New("challenge: blocked by a Cloudflare challenge")
+	ErrNotFound       = errors.New("not_found: page does not exist")
+	ErrLayoutChanged  = errors.New("layout_changed: page layout is not recognised")
+	ErrManualDownload = errors.New("manual_download: the author only allows downloads from the project page")
+	ErrNotInstallable = errors.New("not_installable: this category cannot be installed into the game directly")
+	ErrNotConfigured  = errors.New("not_configured: the source needs an API key that is not set")
+)
+
+var challengeMarkers = [][]byte{
+	[]byte("cf-chl-"),
+	[]byte("challenge-platform"),
+	[]byte("cf_chl_opt"),
+	[]byte("<title>Just a moment...</title>"),
+}
+
+// ScrapeError ties a scraper failure to the URL it happened on.
+type ScrapeError struct {
+	URL    string
+	Status int
+	Err    error
+}
+
+func (e *ScrapeError) Error() string {
+	if e.Status != 0 {
+		return fmt.Sprintf("%v (%s, status %d)", e.Err, e.URL, e.Status)
+	}
+	return fmt.Sprintf("%v (%s)", e.Err, e.URL)
+}
+
+func (e *ScrapeError) Unwrap() error {
+	return e.Err
+}
+
+// scrapeErrors collects errors reported from colly callbacks, which run on
+// the collector's own goroutines.
+type scrapeErrors struct {
+	mu   sync.Mutex
+	errs []error
+}
+
+func (s *scrapeErrors) add(err error) {
+	if err == nil {
+		return
+	}
+	s.mu.Lock()
+	s.errs = append(s.errs, err)
+	s.mu.Unlock()
+}
+
+func (s *scrapeErrors) addURL(url string, status int, err error) {
+	s.add(&ScrapeError{URL: url, Status: status, Err: err})
+}
+
+func (s *scrapeErrors) err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return errors.Join(s.errs...)
+}
+
+// setupErrorHandler records every failed or challenged response on c.
+func setupErrorHandler(c *colly.Collector) *scrapeErrors {
+	errs := &scrapeErrors{}
+
+	c.OnError(func(r *colly.Response, err error) {
+		errs.addURL(r.Request.URL.String(), r.StatusCode, classifyResponse(r.StatusCode, r.Body, err))
+	})
+
+	c.OnResponse(func(r *colly.Response) {
+		if isChallenge(r.Body) {
+			errs.addURL(r.Request.URL.String(), r.StatusCode, ErrChallenge)
+		}
+	})
+
+	return errs
+}
+
+func classifyResponse(status int, body []byte, err error) error {
+	switch {
+	case isChallenge(body):
+		return ErrChallenge
+	case status == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case status == http.StatusNotFound || status == http.StatusGone:
+		return ErrNotFound
+	case err != nil:
+		return err
+	default:
+		return fmt.Errorf("unexpected status %d", status)
+	}
+}
+
+func isChallenge(body []byte) bool {
+	for _, marker := range challengeMarkers {
+		if bytes.Contains(body, marker) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,246 @@
+package parser
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const (
+	fixtureListURL = "https://minecraft-inside.ru/mods/page/2/"
+	fixtureModURL  = "https://minecraft-inside.ru/mods/110393-just-enough-items-jei.html"
+)
+
+func TestParseModBlock(t *testing.T) {
+	page, err := ScrapeMinecraftInsideModsFull(context.Background(), fixtureListURL, 2)
+	if err != nil {
+		t.Fatalf("ScrapeMinecraftInsideModsFull: %v", err)
+	}
+
+	want := []MinecraftMod{
+		{
+			Name:        "Just Enough Items (JEI)",
+			Icon:        "https://minecraft-inside.ru/uploads/posts/2023-06/mini/1686912345_jei.png",
+			ModPageLink: "https://minecraft-inside.ru/mods/110393-just-enough-items-jei.html",
+			Description: "Мод показывает все рецепты предметов и блоков.",
+			Versions:    []string{"1.20.1", "1.19.4", "1.18.2"},
+			Loaders:     []string{"Forge", "Fabric"},
+			Downloads:   1234567,
+			Updated:     time.Date(2024, time.March, 12, 14, 5, 0, 0, moscow),
+		},
+		{
+			Name:        "JourneyMap",
+			Icon:        "https://minecraft-inside.ru/uploads/posts/2023-01/mini/1673000000_journeymap.jpg",
+			ModPageLink: "https://minecraft-inside.ru/mods/98765-journeymap.html",
+			Versions:    []string{"1.20.1"},
+			Loaders:     []string{"Forge"},
+			Downloads:   56700,
+			Updated:     time.Date(2023, time.May, 3, 0, 0, 0, 0, moscow),
+		},
+	}
+	if len(page.Items) != len(want) {
+		t.Fatalf("got %d mods, want %d: %+v", len(page.Items), len(want), page.Items)
+	}
+	for i, got := range page.Items {
+		if !got.Updated.Equal(want[i].Updated) {
+			t.Errorf("mod %d: Updated = %v, want %v", i, got.Updated, want[i].Updated)
+		}
+		got.Updated, want[i].Updated = time.Time{}, time.Time{}
+		if !reflect.DeepEqual(got, want[i]) {
+			t.Errorf("mod %d:\ngot  %+v\nwant %+v", i, got, want[i])
+		}
+	}
+
+	wantPagination := Pagination{Page: 2, TotalPages: 412, HasNext: true}
+	if page.Pagination != wantPagination {
+		t.Errorf("pagination = %+v, want %+v", page.Pagination, wantPagination)
+	}
+}
+
+func TestSetupDetailsHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		versions []string
+		want     []string
+	}{
+		{"exact", []string{"1.20.1"}, []string{"401234"}},
+		{"inside range", []string{"1.19.3"}, []string{"401235"}},
+		{"several", []string{"1.18.2", "1.20.1"}, []string{"401234", "401236"}},
+		{"none", []string{"1.12.2"}, nil},
+		{"no filter", nil, []string{"401234", "401235", "401236"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newCollector(context.Background())
+			errs := setupErrorHandler(c)
+			details := setupDetailsHandler(c, tt.versions)
+			if err := c.Visit(fixtureModURL); err != nil {
+				t.Fatal(err)
+			}
+			c.Wait()
+			if err := errs.err(); err != nil {
+				t.Fatal(err)
+			}
+
+			var ids []string
+			for _, f := range details() {
+				ids = append(ids, f.FileID)
+			}
+			if !reflect.DeepEqual(ids, tt.want) {
+				t.Errorf("file IDs = %v, want %v", ids, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetupDetailsHandlerRows(t *testing.T) {
+	c := newCollector(context.Background())
+	details := setupDetailsHandler(c, []string{"1.19.4", "1.20.1"})
+	if err := c.Visit(fixtureModURL); err != nil {
+		t.Fatal(err)
+	}
+	c.Wait()
+
+	want := []ModFile{
+		{
+			Source:        MinecraftInsideID,
+			FileID:        "401234",
+			Versions:      []string{"1.20.1"},
+			Loaders:       []string{"Forge"},
+			URL:           "https://minecraft-inside.ru/download/401234/",
+			Downloads:     "12 345",
+			DownloadCount: 12345,
+		},
+		{
+			Source:        MinecraftInsideID,
+			FileID:        "401235",
+			Versions:      []string{"1.19.2–1.19.4"},
+			Loaders:       []string{"Fabric", "Forge"},
+			URL:           "https://minecraft-inside.ru/download/401235/",
+			Downloads:     "3 400",
+			DownloadCount: 3400,
+		},
+	}
+	compareFiles(t, details(), want)
+}
+
+func TestSetupMinecraftModDetails(t *testing.T) {
+	files, err := ScrapeMinecraftModDetails(context.Background(), fixtureModURL)
+	if err != nil {
+		t.Fatalf("ScrapeMinecraftModDetails: %v", err)
+	}
+
+	want := []ModFile{
+		{
+			Source:        MinecraftInsideID,
+			FileID:        "401234",
+			Versions:      []string{"1.20.1"},
+			Loaders:       []string{"forge"},
+			URL:           "https://minecraft-inside.ru/download/401234/",
+			Size:          "1,2 МБ",
+			SizeBytes:     1258291,
+			Date:          "12.03.2024 в 14:05",
+			Published:     time.Date(2024, time.March, 12, 14, 5, 0, 0, moscow),
+			Downloads:     "12345",
+			DownloadCount: 12345,
+			Changelog:     "Исправлен вылет с **Create**",
+		},
+		{
+			Source:        MinecraftInsideID,
+			FileID:        "401235",
+			Versions:      []string{"1.19.2–1.19.4"},
+			Loaders:       []string{"fabric", "forge"},
+			URL:           "https://minecraft-inside.ru/download/401235/",
+			Size:          "980 КБ",
+			SizeBytes:     980 << 10,
+			Date:          "1 февраля 2024",
+			Published:     time.Date(2024, time.February, 1, 0, 0, 0, 0, moscow),
+			Downloads:     "3,4 тыс.",
+			DownloadCount: 3400,
+		},
+		{
+			Source:        MinecraftInsideID,
+			FileID:        "401236",
+			Versions:      []string{"1.18.2"},
+			Loaders:       []string{"forge"},
+			URL:           "https://minecraft-inside.ru/download/401236/",
+			Size:          "512 KB",
+			SizeBytes:     512 << 10,
+			Date:          "1700000000",
+			Published:     time.Unix(1700000000, 0),
+			Downloads:     "15",
+			DownloadCount: 15,
+		},
+	}
+	compareFiles(t, files, want)
+}
+
+// compareFiles compares files field by field, with dates compared as
+// instants so the time zone they were parsed in does not matter.
+func compareFiles(t *testing.T, got, want []ModFile) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("got %d files, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range got {
+		g, w := got[i], want[i]
+		if !g.Published.Equal(w.Published) {
+			t.Errorf("file %d: Published = %v, want %v", i, g.Published, w.Published)
+		}
+		g.Published, w.Published = time.Time{}, time.Time{}
+		if !reflect.DeepEqual(g, w) {
+			t.Errorf("file %d:\ngot  %+v\nwant %+v", i, g, w)
+		}
+	}
+}
+
+func TestScrapDetails(t *testing.T) {
+	mod, err := ScrapDetails(context.Background(), fixtureModURL, []string{"1.20.1"})
+	if err != nil {
+		t.Fatalf("ScrapDetails: %v", err)
+	}
+
+	if mod.Name != "Just Enough Items (JEI)" || mod.Author != "mezz" || mod.Rating != 4.8 {
+		t.Errorf("name, author, rating = %q, %q, %v", mod.Name, mod.Author, mod.Rating)
+	}
+	if want := []string{"Интерфейс", "Утилиты"}; !reflect.DeepEqual(mod.Categories, want) {
+		t.Errorf("categories = %v, want %v", mod.Categories, want)
+	}
+	if want := time.Date(2024, time.March, 12, 14, 5, 0, 0, moscow); !mod.Updated.Equal(want) {
+		t.Errorf("updated = %v, want %v", mod.Updated, want)
+	}
+	if mod.Downloads != 12345+3400 {
+		t.Errorf("downloads = %d, want the sum of the file counters", mod.Downloads)
+	}
+	if len(mod.Screenshots) != 1 {
+		t.Errorf("screenshots = %v, want one after deduplication", mod.Screenshots)
+	}
+	if len(mod.Dependency) != 1 || mod.Dependency[0].ModPageLink != "https://minecraft-inside.ru/mods/12345-fabric-api.html" {
+		t.Errorf("dependencies = %+v, want only Fabric API", mod.Dependency)
+	}
+	if len(mod.Details) != 1 || mod.Details[0].FileID != "401234" {
+		t.Errorf("details = %+v, want only file 401234", mod.Details)
+	}
+}
+
+// TestScrapeDependency resolves two links in one call, so that under -race
+// the callbacks of the first page run while the second is being visited.
+func TestScrapeDependency(t *testing.T) {
+	missing := "https://minecraft-inside.ru/mods/12345-fabric-api.html"
+	deps := []ModDependency{
+		{ModPageLink: fixtureModURL, Name: "JEI"},
+		{ModPageLink: missing, Name: "Fabric API"},
+	}
+
+	deps, err := ScrapeDependency(context.Background(), deps, []string{"1.20.1"})
+	if err == nil {
+		t.Errorf("ScrapeDependency: want the error of %s, which has no fixture", missing)
+	}
+	if len(deps[0].Details) != 1 || len(deps[0].Dependency) != 1 {
+		t.Errorf("JEI = %+v, want one file and one dependency", deps[0])
+	}
+	if len(deps[1].Details) != 0 {
+		t.Errorf("Fabric API details = %+v, want none", deps[1].Details)
+	}
+}
@@ -0,0 +1,51 @@
+package parser
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/lanxre/mc-launcher/backend/operations"
+)
+
+// fakeMatcher answers MatchFile with a fixed mod or error.
+type fakeMatcher struct {
+	ModSource
+	id  string
+	mod MinecraftMod
+	err error
+}
+
+func (s fakeMatcher) ID() string { return s.id }
+
+func (s fakeMatcher) MatchFile(ctx context.Context, data []byte) (MinecraftMod, error) {
+	return s.mod, s.err
+}
+
+func TestIdentifyModFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mod.jar")
+	if err := os.WriteFile(path, []byte("jar"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewScraperService(operations.NewRegistry(),
+		fakeMatcher{id: "modrinth", mod: MinecraftMod{Name: "JEI"}},
+		NewCurseForgeSource("", ""),
+		fakeMatcher{id: "unknown", err: ErrNotFound},
+		fakeMatcher{id: "broken", err: errors.New("unexpected status 500")},
+	)
+
+	got, err := s.IdentifyModFile("", path)
+	if err != nil {
+		t.Fatalf("IdentifyModFile: %v", err)
+	}
+	if len(got.Matches) != 1 || got.Matches[0].Name != "JEI" {
+		t.Errorf("matches = %+v, want JEI", got.Matches)
+	}
+	if want := map[string]string{"broken": "unexpected status 500"}; !reflect.DeepEqual(got.Failures, want) {
+		t.Errorf("failures = %v, want %v", got.Failures, want)
+	}
+}
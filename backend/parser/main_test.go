@@ -0,0 +1,34 @@
+package parser
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/lanxre/mc-launcher/backend/nettools"
+)
+
+// TestMain serves every request from the pages recorded under
+// testdata/fixtures and keeps cookies and caches out of the user's data
+// directory. Re-record with MC_LAUNCHER_HTTP_MODE=record, see
+// testdata/README.md.
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "mc-launcher-test")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	os.Setenv("HOME", dir)
+	os.Setenv("XDG_CONFIG_HOME", dir)
+	os.Setenv("AppData", dir)
+
+	mode := nettools.ReplayMode(os.Getenv("MC_LAUNCHER_HTTP_MODE"))
+	if mode != nettools.ModeRecord {
+		mode = nettools.ModeReplay
+	}
+	nettools.UseReplay(mode, "testdata/fixtures")
+
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
@@ -0,0 +1,321 @@
+package parser
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	goldmarkhtml "github.com/yuin/goldmark/renderer/html"
+	"golang.org/x/net/html"
+)
+
+// markdownEscaper escapes the characters that would start Markdown syntax
+// or raw HTML in text taken from a page.
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "*", `\*`, "_", `\_`, "`", "\\`",
+	"[", `\[`, "]", `\]`, "<", "&lt;", ">", "&gt;",
+)
+
+// droppedElements are left out of the Markdown together with their content.
+var droppedElements = map[string]bool{
+	"script": true, "style": true, "noscript": true, "iframe": true,
+	"form": true, "button": true, "input": true, "select": true,
+	"textarea": true, "object": true, "embed": true, "svg": true,
+}
+
+// htmlToMarkdown converts the content of sel into Markdown. Only text,
+// basic formatting, lists, quotes, code and http(s) links and images
+// survive, so the result is safe to render without further sanitising.
+func htmlToMarkdown(sel *goquery.Selection, base *url.URL) string {
+	w := markdownWriter{base: base}
+	for _, n := range sel.Nodes {
+		w.children(n)
+	}
+	return strings.TrimSpace(w.b.String())
+}
+
+// htmlFragmentToMarkdown converts an HTML snippet, such as an API field or
+// a changelog, into Markdown.
+func htmlFragmentToMarkdown(fragment string) string {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(fragment))
+	if err != nil {
+		return ""
+	}
+	return htmlToMarkdown(doc.Find("body"), nil)
+}
+
+type markdownWriter struct {
+	b     strings.Builder
+	base  *url.URL
+	lists []int // item counter per open list, -1 for bullets
+	quote int
+	pre   bool
+}
+
+func (w *markdownWriter) children(n *html.Node) {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		w.node(c)
+	}
+}
+
+func (w *markdownWriter) node(n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		w.text(n.Data)
+		return
+	case html.ElementNode:
+	default:
+		return
+	}
+
+	tag := n.Data
+	if droppedElements[tag] {
+		return
+	}
+
+	switch tag {
+	case "br":
+		w.b.WriteString("  \n" + w.prefix())
+	case "hr":
+		w.block()
+		w.b.WriteString("---")
+		w.block()
+	case "p", "div", "section", "article", "center", "figure", "table":
+		w.block()
+		w.children(n)
+		w.block()
+	case "tr":
+		w.newline()
+		w.children(n)
+	case "td", "th":
+		w.children(n)
+		w.b.WriteString(" ")
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		w.block()
+		w.b.WriteString(strings.Repeat("#", int(tag[1]-'0')) + " ")
+		w.children(n)
+		w.block()
+	case "strong", "b":
+		w.wrap(n, "**")
+	case "em", "i":
+		w.wrap(n, "_")
+	case "s", "del", "strike":
+		w.wrap(n, "~~")
+	case "code":
+		if w.pre {
+			w.children(n)
+		} else {
+			w.b.WriteString("`" + strings.ReplaceAll(nodeText(n), "`", "'") + "`")
+		}
+	case "pre":
+		w.block()
+		w.b.WriteString("```\n")
+		w.pre = true
+		w.children(n)
+		w.pre = false
+		w.trimRight("\n")
+		w.b.WriteString("\n```")
+		w.block()
+	case "blockquote":
+		w.block()
+		w.quote++
+		w.b.WriteString(w.prefix())
+		w.children(n)
+		w.quote--
+		w.block()
+	case "ul", "ol":
+		nested := len(w.lists) > 0
+		if !nested {
+			w.block()
+		}
+		counter := -1
+		if tag == "ol" {
+			counter = 0
+		}
+		w.lists = append(w.lists, counter)
+		w.children(n)
+		w.lists = w.lists[:len(w.lists)-1]
+		if !nested {
+			w.block()
+		}
+	case "li":
+		w.listItem(n)
+	case "a":
+		w.link(n)
+	case "img":
+		if src := w.safeURL(attr(n, "src")); src != "" {
+			w.b.WriteString("![" + markdownEscaper.Replace(attr(n, "alt")) + "](" + src + ")")
+		}
+	default:
+		w.children(n)
+	}
+}
+
+func (w *markdownWriter) text(s string) {
+	if w.pre {
+		w.b.WriteString(strings.ReplaceAll(s, "```", "'''"))
+		return
+	}
+	collapsed := strings.Join(strings.Fields(s), " ")
+	if collapsed == "" {
+		if s != "" && !w.atLineStart() {
+			w.b.WriteString(" ")
+		}
+		return
+	}
+	if startsWithSpace(s) && !w.atLineStart() {
+		w.b.WriteString(" ")
+	}
+	w.b.WriteString(markdownEscaper.Replace(collapsed))
+	if endsWithSpace(s) {
+		w.b.WriteString(" ")
+	}
+}
+
+func (w *markdownWriter) wrap(n *html.Node, marker string) {
+	inner := markdownWriter{base: w.base}
+	inner.children(n)
+	if text := strings.TrimSpace(inner.b.String()); text != "" {
+		w.b.WriteString(marker + text + marker)
+	}
+}
+
+func (w *markdownWriter) link(n *html.Node) {
+	inner := markdownWriter{base: w.base}
+	inner.children(n)
+	text := strings.TrimSpace(inner.b.String())
+
+	href := w.safeURL(attr(n, "href"))
+	switch {
+	case href == "":
+		w.b.WriteString(text)
+	case text == "":
+		w.b.WriteString("<" + href + ">")
+	default:
+		w.b.WriteString("[" + text + "](" + href + ")")
+	}
+}
+
+func (w *markdownWriter) listItem(n *html.Node) {
+	w.newline()
+	depth := len(w.lists)
+	marker := "- "
+	if depth > 0 && w.lists[depth-1] >= 0 {
+		w.lists[depth-1]++
+		marker = fmt.Sprintf("%d. ", w.lists[depth-1])
+	}
+	w.b.WriteString(strings.Repeat("  ", max(depth-1, 0)) + marker)
+	w.children(n)
+}
+
+// safeURL resolves ref against the page and keeps it only if it is an
+// http(s) URL, dropping javascript: and data: links.
+func (w *markdownWriter) safeURL(ref string) string {
+	u, err := url.Parse(strings.TrimSpace(ref))
+	if err != nil || ref == "" {
+		return ""
+	}
+	if w.base != nil {
+		u = w.base.ResolveReference(u)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return ""
+	}
+	return strings.NewReplacer("(", "%28", ")", "%29", " ", "%20").Replace(u.String())
+}
+
+func (w *markdownWriter) prefix() string {
+	return strings.Repeat("> ", w.quote)
+}
+
+func (w *markdownWriter) atLineStart() bool {
+	s := w.b.String()
+	return s == "" || strings.HasSuffix(s, "\n") || strings.HasSuffix(s, "> ")
+}
+
+// newline starts a new line unless the output is already at one.
+func (w *markdownWriter) newline() {
+	if !w.atLineStart() {
+		w.trimRight(" ")
+		w.b.WriteString("\n" + w.prefix())
+	}
+}
+
+// trimRight drops trailing cutset characters from the output.
+func (w *markdownWriter) trimRight(cutset string) {
+	s := strings.TrimRight(w.b.String(), cutset)
+	w.b.Reset()
+	w.b.WriteString(s)
+}
+
+// block separates block elements by a blank line, which inside a quote
+// is a line holding only the quote markers.
+func (w *markdownWriter) block() {
+	s := strings.TrimRight(w.b.String(), " >\n")
+	if s == "" {
+		return
+	}
+	w.b.Reset()
+	w.b.WriteString(s)
+	if w.quote > 0 {
+		w.b.WriteString("\n" + strings.TrimSpace(w.prefix()) + "\n" + w.prefix())
+	} else {
+		w.b.WriteString("\n\n")
+	}
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+func nodeText(n *html.Node) string {
+	var b strings.Builder
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			b.WriteString(n.Data)
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(n)
+	return strings.TrimSpace(b.String())
+}
+
+func startsWithSpace(s string) bool {
+	return s != "" && strings.TrimLeft(s, " \t\n\r") != s
+}
+
+func endsWithSpace(s string) bool {
+	return s != "" && strings.TrimRight(s, " \t\n\r") != s
+}
+
+// authorMarkdown renders Markdown written by mod authors to HTML. Raw HTML
+// and every link target are passed through untouched, as the HTML is only
+// read back by htmlToMarkdown, which keeps nothing unsafe.
+var authorMarkdown = goldmark.New(
+	goldmark.WithExtensions(extension.GFM),
+	goldmark.WithRendererOptions(goldmarkhtml.WithUnsafe()),
+)
+
+// sanitizeMarkdown makes Markdown written by mod authors as safe as the
+// Markdown converted from pages by rendering it to HTML and converting that
+// back, so raw HTML, reference links and autolinks all go through the
+// same allowlist.
+func sanitizeMarkdown(md string) string {
+	var b bytes.Buffer
+	if err := authorMarkdown.Convert([]byte(md), &b); err != nil {
+		return markdownEscaper.Replace(md)
+	}
+	return htmlFragmentToMarkdown(b.String())
+}
@@ -0,0 +1,59 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSanitizeMarkdown(t *testing.T) {
+	tests := []struct {
+		name string
+		md   string
+		want string
+	}{
+		{"text", "Hello **world**", "Hello **world**"},
+		{"http link", "[site](https://example.com/a)", "[site](https://example.com/a)"},
+		{"list", "- one\n- two", "- one\n- two"},
+		{"raw image", `<img src="https://cdn.example.com/a.png" alt="shot">`, "![shot](https://cdn.example.com/a.png)"},
+		{"fenced code", "```\n<script>x</script>\n```", "```\n<script>x</script>\n```"},
+		{"script link", "[a](javascript:alert(1))", "a"},
+		{"angle link", "[a](<javascript:alert(1)>)", "a"},
+		{"autolink", "<javascript:alert(1)>", "javascript:alert(1)"},
+		{"reference link", "[r]\n\n[r]: javascript:alert(1)", "r"},
+		{"data image", "![x](data:image/svg+xml;base64,AAAA)", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sanitizeMarkdown(tt.md); got != tt.want {
+				t.Errorf("sanitizeMarkdown(%q) = %q, want %q", tt.md, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestSanitizeMarkdownUnsafe checks that nothing able to run script
+// survives, whatever shape the Markdown around it has.
+func TestSanitizeMarkdownUnsafe(t *testing.T) {
+	inputs := []string{
+		"<img\nsrc=x onerror=alert(1)>",
+		"<img src=\"https://a.example/x.png\"\nonerror=alert(1)>",
+		"<a href=\"javascript:alert(1)\">x</a>",
+		"<script>alert(1)</script>",
+		"<div\nonmouseover=alert(1)>hover</div>",
+		"<svg><script>alert(1)</script></svg>",
+		"[r]: javascript:alert(1)\n\n[click][r]",
+		"<javascript:alert(1)>",
+		"[a](<javascript:alert(1)>)",
+		"[a](JaVaScRiPt:alert(1))",
+		"[a](vbscript:msgbox(1))",
+		"<iframe src=\"https://evil.example\"></iframe>",
+	}
+	for _, md := range inputs {
+		got := strings.ToLower(sanitizeMarkdown(md))
+		for _, bad := range []string{"<img", "<a ", "<script", "<div", "<svg", "<iframe", "onerror", "onmouseover", "](javascript:", "](vbscript:", "<javascript:"} {
+			if strings.Contains(got, bad) {
+				t.Errorf("sanitizeMarkdown(%q) = %q, contains %q", md, got, bad)
+			}
+		}
+	}
+}
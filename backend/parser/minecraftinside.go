@@ -0,0 +1,86 @@
+package parser
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+const (
+	baseURL  = "https://minecraft-inside.ru"
+	modsPath = "mods"
+
+	MinecraftInsideID = "minecraft-inside"
+)
+
+type minecraftInsideSource struct{}
+
+func NewMinecraftInsideSource() ModSource {
+	return &minecraftInsideSource{}
+}
+
+func (s *minecraftInsideSource) ID() string {
+	return MinecraftInsideID
+}
+
+func (s *minecraftInsideSource) Name() string {
+	return "minecraft-inside.ru"
+}
+
+// Search lists the site's mods in its own order, so query.Sort is ignored.
+func (s *minecraftInsideSource) Search(ctx context.Context, query SearchQuery) (ModsPage, error) {
+	return s.withSource(scanFiltered(query, s.serverFilters(query), func(page int) (ModsPage, error) {
+		return ScrapeMinecraftInsideModsFull(ctx, buildURL(modsPath, page, &query.Text), page)
+	}))
+}
+
+func (s *minecraftInsideSource) ListPage(ctx context.Context, page int) (ModsPage, error) {
+	return s.Search(ctx, SearchQuery{Page: page})
+}
+
+// serverFilters reports which filters the site applies itself. Only the
+// search text is known to be taken by the mods list, so version, loader
+// and category are all filtered on the scraped pages.
+func (s *minecraftInsideSource) serverFilters(query SearchQuery) queryFilter {
+	return 0
+}
+
+func (s *minecraftInsideSource) GetDetails(ctx context.Context, link string, versions []string) (MinecraftMod, error) {
+	mod, err := ScrapDetails(ctx, link, versions)
+	mod.Source = s.ID()
+	return mod, err
+}
+
+func (s *minecraftInsideSource) GetFiles(ctx context.Context, link string) ([]ModFile, error) {
+	return ScrapeMinecraftModDetails(ctx, link)
+}
+
+func (s *minecraftInsideSource) ResolveDependencies(ctx context.Context, depends []ModDependency, versions []string) ([]ModDependency, error) {
+	return ScrapeDependency(ctx, depends, versions)
+}
+
+func (s *minecraftInsideSource) withSource(page ModsPage, err error) (ModsPage, error) {
+	for i := range page.Items {
+		page.Items[i].Source = s.ID()
+	}
+	return page, err
+}
+
+func buildURL(category string, page int, search *string) string {
+	params := url.Values{}
+	if search != nil && *search != "" {
+		params.Set("q", *search)
+	}
+	return buildQueryURL(category, page, params)
+}
+
+func buildQueryURL(category string, page int, params url.Values) string {
+	if page < 1 {
+		page = 1
+	}
+	base := fmt.Sprintf("%s/%s/page/%d/", baseURL, category, page)
+	if len(params) > 0 {
+		return base + "?" + params.Encode()
+	}
+	return base
+}
@@ -0,0 +1,491 @@
+package parser
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	ModrinthID             = "modrinth"
+	DefaultModrinthBaseURL = "https://api.modrinth.com/v2"
+
+	modrinthSiteURL  = "https://modrinth.com"
+	modrinthPageSize = 20
+)
+
+var modrinthSortIndex = map[SortOrder]string{
+	SortRelevance: "relevance",
+	SortPopular:   "follows",
+	SortNewest:    "newest",
+	SortUpdated:   "updated",
+	SortDownloads: "downloads",
+}
+
+type modrinthSource struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewModrinthSource returns a source backed by the Modrinth v2 API at
+// baseURL, or at the public API when baseURL is empty.
+func NewModrinthSource(baseURL string) ModSource {
+	if baseURL == "" {
+		baseURL = DefaultModrinthBaseURL
+	}
+	return &modrinthSource{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		client:  newAPIClient(),
+	}
+}
+
+type modrinthSearchResponse struct {
+	Hits      []modrinthHit `json:"hits"`
+	Offset    int           `json:"offset"`
+	Limit     int           `json:"limit"`
+	TotalHits int           `json:"total_hits"`
+}
+
+type modrinthHit struct {
+	ProjectID   string   `json:"project_id"`
+	ProjectType string   `json:"project_type"`
+	Slug        string   `json:"slug"`
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	Categories  []string `json:"categories"`
+	Versions    []string `json:"versions"`
+	IconURL     string   `json:"icon_url"`
+	Gallery     []string `json:"gallery"`
+	Downloads   int64    `json:"downloads"`
+	DateUpdated string   `json:"date_modified"`
+	Author      string   `json:"author"`
+}
+
+type modrinthProject struct {
+	ID           string   `json:"id"`
+	Slug         string   `json:"slug"`
+	ProjectType  string   `json:"project_type"`
+	Title        string   `json:"title"`
+	Description  string   `json:"description"`
+	GameVersions []string `json:"game_versions"`
+	Loaders      []string `json:"loaders"`
+	IconURL      string   `json:"icon_url"`
+	Downloads    int64    `json:"downloads"`
+	Updated      string   `json:"updated"`
+	Published    string   `json:"published"`
+	Categories   []string `json:"categories"`
+	Body         string   `json:"body"`
+	SourceURL    string   `json:"source_url"`
+	IssuesURL    string   `json:"issues_url"`
+	WikiURL      string   `json:"wiki_url"`
+	DiscordURL   string   `json:"discord_url"`
+	Gallery      []struct {
+		URL string `json:"url"`
+	} `json:"gallery"`
+}
+
+type modrinthVersion struct {
+	ID            string         `json:"id"`
+	ProjectID     string         `json:"project_id"`
+	Name          string         `json:"name"`
+	VersionNumber string         `json:"version_number"`
+	GameVersions  []string       `json:"game_versions"`
+	Loaders       []string       `json:"loaders"`
+	Downloads     int            `json:"downloads"`
+	DatePublished string         `json:"date_published"`
+	Changelog     string         `json:"changelog"`
+	Files         []modrinthFile `json:"files"`
+	Dependencies  []struct {
+		VersionID      string `json:"version_id"`
+		ProjectID      string `json:"project_id"`
+		FileName       string `json:"file_name"`
+		DependencyType string `json:"dependency_type"`
+	} `json:"dependencies"`
+}
+
+type modrinthFile struct {
+	Hashes   map[string]string `json:"hashes"`
+	URL      string            `json:"url"`
+	Filename string            `json:"filename"`
+	Primary  bool              `json:"primary"`
+	Size     int64             `json:"size"`
+}
+
+func (s *modrinthSource) ID() string {
+	return ModrinthID
+}
+
+func (s *modrinthSource) Name() string {
+	return "Modrinth"
+}
+
+// Search passes every filter on as a facet, except version ranges, which
+// Modrinth cannot express and are applied to the results instead.
+func (s *modrinthSource) Search(ctx context.Context, query SearchQuery) (ModsPage, error) {
+	serverSide := filterVersion | filterLoader | filterCategory
+	if _, ok := exactVersions(query.Versions); !ok {
+		serverSide &^= filterVersion
+	}
+	return scanFiltered(query, serverSide, func(page int) (ModsPage, error) {
+		return s.search(ctx, query, serverSide, page)
+	})
+}
+
+func (s *modrinthSource) search(ctx context.Context, query SearchQuery, serverSide queryFilter, page int) (ModsPage, error) {
+	params := url.Values{}
+	params.Set("query", query.Text)
+	params.Set("facets", modrinthFacets(query, serverSide))
+	params.Set("offset", strconv.Itoa((page-1)*modrinthPageSize))
+	params.Set("limit", strconv.Itoa(modrinthPageSize))
+	if index, ok := modrinthSortIndex[query.Sort]; ok {
+		params.Set("index", index)
+	}
+
+	var resp modrinthSearchResponse
+	if err := s.get(ctx, "/search?"+params.Encode(), &resp); err != nil {
+		return ModsPage{}, err
+	}
+
+	mods := make([]MinecraftMod, 0, len(resp.Hits))
+	for _, hit := range resp.Hits {
+		mods = append(mods, MinecraftMod{
+			Source:      s.ID(),
+			Name:        hit.Title,
+			Icon:        hit.IconURL,
+			ModPageLink: modrinthPageLink(hit.ProjectType, hit.Slug),
+			Description: hit.Description,
+			Versions:    hit.Versions,
+			Screenshots: hit.Gallery,
+			Loaders:     modrinthLoaders(hit.Categories),
+			Categories:  modrinthCategories(hit.Categories),
+			Downloads:   hit.Downloads,
+			Updated:     parseDate(hit.DateUpdated),
+			Author:      hit.Author,
+		})
+	}
+	return ModsPage{Items: mods, Pagination: NewPagination(page, modrinthPageSize, resp.TotalHits)}, nil
+}
+
+func (s *modrinthSource) ListPage(ctx context.Context, page int) (ModsPage, error) {
+	return s.Search(ctx, SearchQuery{Page: page})
+}
+
+func (s *modrinthSource) GetDetails(ctx context.Context, link string, versions []string) (MinecraftMod, error) {
+	project, err := s.project(ctx, modrinthProjectID(link))
+	if err != nil {
+		return MinecraftMod{}, err
+	}
+
+	projectVersions, err := s.projectVersions(ctx, project.ID, versions)
+	if err != nil {
+		return MinecraftMod{}, err
+	}
+
+	mod := s.projectToMod(project)
+	if mod.Author, err = s.owner(ctx, project.ID); err != nil {
+		return mod, err
+	}
+	for _, v := range projectVersions {
+		if info, ok := modrinthModFile(v); ok {
+			mod.Details = append(mod.Details, info)
+		}
+	}
+
+	if len(projectVersions) > 0 {
+		mod.Dependency, err = s.requiredDependencies(ctx, projectVersions[0])
+		if err != nil {
+			return mod, err
+		}
+	}
+	return mod, nil
+}
+
+func (s *modrinthSource) GetFiles(ctx context.Context, link string) ([]ModFile, error) {
+	projectVersions, err := s.projectVersions(ctx, modrinthProjectID(link), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []ModFile
+	for _, v := range projectVersions {
+		if file, ok := modrinthModFile(v); ok {
+			files = append(files, file)
+		}
+	}
+	return files, nil
+}
+
+func (s *modrinthSource) ResolveDependencies(ctx context.Context, depends []ModDependency, versions []string) ([]ModDependency, error) {
+	for i := range depends {
+		projectVersions, err := s.projectVersions(ctx, modrinthProjectID(depends[i].ModPageLink), versions)
+		if err != nil {
+			return depends, err
+		}
+
+		depends[i].Source = s.ID()
+		for _, v := range projectVersions {
+			if info, ok := modrinthModFile(v); ok {
+				depends[i].Details = append(depends[i].Details, info)
+			}
+		}
+
+		if len(projectVersions) > 0 {
+			subDeps, err := s.requiredDependencies(ctx, projectVersions[0])
+			if err != nil {
+				return depends, err
+			}
+			depends[i].Dependency = append(depends[i].Dependency, subDeps...)
+		}
+	}
+	return depends, nil
+}
+
+// MatchFile looks the file up by its SHA-1 hash through /version_file.
+func (s *modrinthSource) MatchFile(ctx context.Context, data []byte) (MinecraftMod, error) {
+	sum := sha1.Sum(data)
+	return s.LookupByHash(ctx, hex.EncodeToString(sum[:]), "sha1")
+}
+
+// LookupByHash returns the project owning the file with the given hash.
+// algorithm is either "sha1" or "sha512".
+func (s *modrinthSource) LookupByHash(ctx context.Context, hash, algorithm string) (MinecraftMod, error) {
+	var version modrinthVersion
+	path := fmt.Sprintf("/version_file/%s?algorithm=%s", url.PathEscape(hash), url.QueryEscape(algorithm))
+	if err := s.get(ctx, path, &version); err != nil {
+		return MinecraftMod{}, err
+	}
+
+	project, err := s.project(ctx, version.ProjectID)
+	if err != nil {
+		return MinecraftMod{}, err
+	}
+
+	mod := s.projectToMod(project)
+	if info, ok := modrinthModFile(version); ok {
+		mod.Details = []ModFile{info}
+	}
+	return mod, nil
+}
+
+func (s *modrinthSource) project(ctx context.Context, idOrSlug string) (modrinthProject, error) {
+	var project modrinthProject
+	err := s.get(ctx, "/project/"+url.PathEscape(idOrSlug), &project)
+	return project, err
+}
+
+func (s *modrinthSource) projects(ctx context.Context, ids []string) ([]modrinthProject, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	raw, _ := json.Marshal(ids)
+
+	var projects []modrinthProject
+	err := s.get(ctx, "/projects?ids="+url.QueryEscape(string(raw)), &projects)
+	return projects, err
+}
+
+// projectVersions lists the versions of a project built for any of the
+// given game versions. Ranges are matched locally against the full list.
+func (s *modrinthSource) projectVersions(ctx context.Context, idOrSlug string, versions []string) ([]modrinthVersion, error) {
+	path := "/project/" + url.PathEscape(idOrSlug) + "/version"
+	exact, ok := exactVersions(versions)
+	if ok && len(exact) > 0 {
+		raw, _ := json.Marshal(exact)
+		path += "?game_versions=" + url.QueryEscape(string(raw))
+	}
+
+	var projectVersions []modrinthVersion
+	if err := s.get(ctx, path, &projectVersions); err != nil || ok {
+		return projectVersions, err
+	}
+
+	matched := projectVersions[:0]
+	for _, v := range projectVersions {
+		if matchesVersions(versions, v.GameVersions) {
+			matched = append(matched, v)
+		}
+	}
+	return matched, nil
+}
+
+func (s *modrinthSource) requiredDependencies(ctx context.Context, v modrinthVersion) ([]ModDependency, error) {
+	var ids []string
+	for _, dep := range v.Dependencies {
+		if dep.DependencyType == "required" && dep.ProjectID != "" && !slices.Contains(ids, dep.ProjectID) {
+			ids = append(ids, dep.ProjectID)
+		}
+	}
+
+	projects, err := s.projects(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	depends := make([]ModDependency, 0, len(projects))
+	for _, p := range projects {
+		depends = append(depends, ModDependency{
+			Source:      s.ID(),
+			ModPageLink: modrinthPageLink(p.ProjectType, p.Slug),
+			Name:        p.Title,
+		})
+	}
+	return depends, nil
+}
+
+func (s *modrinthSource) projectToMod(p modrinthProject) MinecraftMod {
+	screenshots := make([]string, 0, len(p.Gallery))
+	for _, img := range p.Gallery {
+		screenshots = append(screenshots, img.URL)
+	}
+	return MinecraftMod{
+		Source:      s.ID(),
+		Name:        p.Title,
+		Icon:        p.IconURL,
+		ModPageLink: modrinthPageLink(p.ProjectType, p.Slug),
+		Description: p.Description,
+		Versions:    p.GameVersions,
+		Screenshots: screenshots,
+		Loaders:     p.Loaders,
+		Categories:  modrinthCategories(p.Categories),
+		Downloads:   p.Downloads,
+		Updated:     parseDate(p.Updated),
+		Published:   parseDate(p.Published),
+		Body:        sanitizeMarkdown(p.Body),
+		Links: ModLinks{
+			Source:  p.SourceURL,
+			Issues:  p.IssuesURL,
+			Wiki:    p.WikiURL,
+			Discord: p.DiscordURL,
+		},
+	}
+}
+
+// owner returns the username of the project owner, or of its first member.
+func (s *modrinthSource) owner(ctx context.Context, projectID string) (string, error) {
+	var members []struct {
+		Role string `json:"role"`
+		User struct {
+			Username string `json:"username"`
+		} `json:"user"`
+	}
+	if err := s.get(ctx, "/project/"+url.PathEscape(projectID)+"/members", &members); err != nil {
+		return "", err
+	}
+	for _, m := range members {
+		if m.Role == "Owner" {
+			return m.User.Username, nil
+		}
+	}
+	if len(members) > 0 {
+		return members[0].User.Username, nil
+	}
+	return "", nil
+}
+
+func (s *modrinthSource) get(ctx context.Context, path string, out any) error {
+	return getJSON(ctx, s.client, s.baseURL+path, nil, out)
+}
+
+func modrinthFacets(query SearchQuery, serverSide queryFilter) string {
+	facets := [][]string{{"project_type:mod"}}
+	if versions, _ := exactVersions(query.Versions); serverSide&filterVersion != 0 && len(versions) > 0 {
+		group := make([]string, 0, len(versions))
+		for _, v := range versions {
+			group = append(group, "versions:"+v)
+		}
+		facets = append(facets, group)
+	}
+	if len(query.Loaders) > 0 {
+		group := make([]string, 0, len(query.Loaders))
+		for _, l := range query.Loaders {
+			group = append(group, "categories:"+strings.ToLower(l))
+		}
+		facets = append(facets, group)
+	}
+	if query.Category != "" {
+		facets = append(facets, []string{"categories:" + strings.ToLower(query.Category)})
+	}
+	raw, _ := json.Marshal(facets)
+	return string(raw)
+}
+
+var modrinthKnownLoaders = []string{"fabric", "forge", "neoforge", "quilt", "liteloader", "rift"}
+
+func modrinthLoaders(categories []string) []string {
+	var loaders []string
+	for _, c := range categories {
+		if slices.Contains(modrinthKnownLoaders, c) {
+			loaders = append(loaders, c)
+		}
+	}
+	return loaders
+}
+
+func modrinthCategories(categories []string) []string {
+	var result []string
+	for _, c := range categories {
+		if !slices.Contains(modrinthKnownLoaders, c) {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+func modrinthPageLink(projectType, slug string) string {
+	if projectType == "" {
+		projectType = "mod"
+	}
+	return fmt.Sprintf("%s/%s/%s", modrinthSiteURL, projectType, slug)
+}
+
+// modrinthProjectID accepts either a modrinth.com page link or a bare
+// project ID/slug.
+func modrinthProjectID(link string) string {
+	link = strings.TrimRight(link, "/")
+	if idx := strings.LastIndexByte(link, '/'); idx != -1 {
+		return link[idx+1:]
+	}
+	return link
+}
+
+func modrinthPrimaryFile(v modrinthVersion) (modrinthFile, bool) {
+	if len(v.Files) == 0 {
+		return modrinthFile{}, false
+	}
+	for _, f := range v.Files {
+		if f.Primary {
+			return f, true
+		}
+	}
+	return v.Files[0], true
+}
+
+func modrinthModFile(v modrinthVersion) (ModFile, bool) {
+	file, ok := modrinthPrimaryFile(v)
+	if !ok {
+		return ModFile{}, false
+	}
+	return ModFile{
+		Source:        ModrinthID,
+		FileID:        v.ID,
+		FileName:      file.Filename,
+		Versions:      v.GameVersions,
+		Loaders:       v.Loaders,
+		URL:           file.URL,
+		Size:          strconv.FormatInt(file.Size, 10),
+		SizeBytes:     file.Size,
+		Date:          v.DatePublished,
+		Published:     parseDate(v.DatePublished),
+		Downloads:     strconv.Itoa(v.Downloads),
+		DownloadCount: int64(v.Downloads),
+		Hashes:        file.Hashes,
+		Changelog:     sanitizeMarkdown(v.Changelog),
+	}, true
+}
@@ -0,0 +1,38 @@
+package parser
+
+import (
+	"context"
+	"time"
+
+	"github.com/lanxre/mc-launcher/backend/nettools"
+	"github.com/lanxre/mc-launcher/backend/operations"
+)
+
+// Freshness marks a result built from cached pages because the site could
+// not be reached. CachedAt and AgeSeconds describe the oldest page used.
+type Freshness struct {
+	Stale      bool      `yaml:"stale"`
+	CachedAt   time.Time `yaml:"cached_at"`
+	AgeSeconds int64     `yaml:"age_seconds"`
+}
+
+func freshness(s *nettools.Staleness) Freshness {
+	cachedAt, stale := s.Oldest()
+	if !stale {
+		return Freshness{}
+	}
+	return Freshness{Stale: true, CachedAt: cachedAt, AgeSeconds: int64(time.Since(cachedAt).Seconds())}
+}
+
+// runTracked is operations.Run that also reports whether fn was answered
+// from the offline cache.
+func runTracked[T any](ops *operations.Registry, opID string, fn func(ctx context.Context) (T, error)) (T, Freshness, error) {
+	var fresh Freshness
+	result, err := operations.Run(ops, opID, func(ctx context.Context) (T, error) {
+		ctx, staleness := nettools.TrackStaleness(ctx)
+		result, err := fn(ctx)
+		fresh = freshness(staleness)
+		return result, err
+	})
+	return result, fresh, err
+}
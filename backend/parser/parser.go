@@ -1,51 +1,250 @@
 package parser
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"strings"
-)
+	"os"
 
-const (
-	baseURL       = "https://minecraft-inside.ru"
-	modsPath      = "mods"
+	"github.com/lanxre/mc-launcher/backend/nettools"
+	"github.com/lanxre/mc-launcher/backend/operations"
 )
 
-type ScraperService struct{}
+// ScraperService is bound to the frontend. Every method that reaches the
+// network takes an operation ID first, which App.CancelOperation cancels.
+type ScraperService struct {
+	ops     *operations.Registry
+	sources *sourceRegistry
+}
+
+func NewScraperService(ops *operations.Registry, sources ...ModSource) *ScraperService {
+	s := &ScraperService{ops: ops, sources: newSourceRegistry()}
+	s.RegisterSource(NewMinecraftInsideSource())
+	for _, src := range sources {
+		s.RegisterSource(src)
+	}
+	return s
+}
+
+// RegisterSource adds src to the service. The first registered source is
+// used by the methods that do not take a source ID.
+func (s *ScraperService) RegisterSource(src ModSource) {
+	s.sources.register(src)
+}
+
+func (s *ScraperService) GetSources() []SourceInfo {
+	return s.sources.list()
+}
+
+func (s *ScraperService) GetMods(opID string) (ModsPage, error) {
+	return s.GetModsByPage(opID, 1, nil)
+}
+
+func (s *ScraperService) GetModsByPage(opID string, page int, inputSearch *string) (ModsPage, error) {
+	return s.GetSourceModsByPage(opID, "", page, inputSearch)
+}
+
+func (s *ScraperService) GetModDetails(opID, link string, versions []string) (MinecraftMod, error) {
+	return s.GetSourceModDetails(opID, "", link, versions)
+}
+
+func (s *ScraperService) GetSearchMods(opID, searchedValue string, page int) (ModsPage, error) {
+	return s.GetModsByPage(opID, page, &searchedValue)
+}
 
-func NewScraperService() *ScraperService {
-	return &ScraperService{}
+func (s *ScraperService) GetModDepends(opID string, depends []ModDependency, versions []string) (DependencyList, error) {
+	return s.GetSourceModDepends(opID, "", depends, versions)
 }
 
-func (s *ScraperService) GetMods() ([]MinecraftMod, error) {
-	return s.GetModsByPage(1, nil)
+func (s *ScraperService) GetSourceModsByPage(opID, sourceID string, page int, inputSearch *string) (ModsPage, error) {
+	src, err := s.sources.get(sourceID)
+	if err != nil {
+		return ModsPage{}, err
+	}
+	result, fresh, err := runTracked(s.ops, opID, func(ctx context.Context) (ModsPage, error) {
+		if inputSearch != nil && *inputSearch != "" {
+			return src.Search(ctx, SearchQuery{Text: *inputSearch, Page: page})
+		}
+		return src.ListPage(ctx, page)
+	})
+	result.Freshness = fresh
+	return result, err
+}
+
+func (s *ScraperService) SearchSourceMods(opID, sourceID string, query SearchQuery) (ModsPage, error) {
+	src, err := s.sources.get(sourceID)
+	if err != nil {
+		return ModsPage{}, err
+	}
+	result, fresh, err := runTracked(s.ops, opID, func(ctx context.Context) (ModsPage, error) {
+		return src.Search(ctx, query)
+	})
+	result.Freshness = fresh
+	return result, err
 }
 
-func (s *ScraperService) GetModsByPage(page int, inputSearch *string) ([]MinecraftMod, error) {
-	url := s.buildURL(modsPath, page, inputSearch)
-	return ScrapeMinecraftInsideModsFull(url)
+func (s *ScraperService) GetSourceModDetails(opID, sourceID, link string, versions []string) (MinecraftMod, error) {
+	src, err := s.sources.get(sourceID)
+	if err != nil {
+		return MinecraftMod{}, err
+	}
+	mod, fresh, err := runTracked(s.ops, opID, func(ctx context.Context) (MinecraftMod, error) {
+		return src.GetDetails(ctx, link, versions)
+	})
+	mod.Freshness = fresh
+	return mod, err
 }
 
-func (s *ScraperService) GetModDetails(link string, versions []string) (MinecraftMod, error) {
-	return ScrapDetails(link, versions)
+func (s *ScraperService) GetSourceModFiles(opID, sourceID, link string) (ModFileList, error) {
+	src, err := s.sources.get(sourceID)
+	if err != nil {
+		return ModFileList{}, err
+	}
+	files, fresh, err := runTracked(s.ops, opID, func(ctx context.Context) ([]ModFile, error) {
+		return src.GetFiles(ctx, link)
+	})
+	return ModFileList{Items: files, Freshness: fresh}, err
 }
 
-func (s *ScraperService) GetSearchMods(searchedValue string, page int) ([]MinecraftMod, error) {
-	return s.GetModsByPage(page, &searchedValue)
+func (s *ScraperService) GetSourceModDepends(opID, sourceID string, depends []ModDependency, versions []string) (DependencyList, error) {
+	src, err := s.sources.get(sourceID)
+	if err != nil {
+		return DependencyList{}, err
+	}
+	deps, fresh, err := runTracked(s.ops, opID, func(ctx context.Context) ([]ModDependency, error) {
+		return src.ResolveDependencies(ctx, depends, versions)
+	})
+	return DependencyList{Items: deps, Freshness: fresh}, err
 }
 
-func (s *ScraperService) GetModDepends(depends []ModDependency, versions []string) []ModDependency {
-	return ScrapeDependency(depends, versions)
+// GetChangelogBetween returns the combined changelog of the files released
+// between the installed and the candidate file of the mod at link.
+func (s *ScraperService) GetChangelogBetween(opID, sourceID, link, installedFileID, candidateFileID string) (Changelog, error) {
+	src, err := s.sources.get(sourceID)
+	if err != nil {
+		return Changelog{}, err
+	}
+	return operations.Run(s.ops, opID, func(ctx context.Context) (Changelog, error) {
+		return BuildChangelog(ctx, src, link, installedFileID, candidateFileID)
+	})
 }
 
-func (s *ScraperService) GetMinecraftModDetailsV1(modUrl string) []MinecraftModDetails {
-	return ScrapeMinecraftModDetails(modUrl)
+// ResolveInstallPlan resolves the whole dependency graph of the mod at link
+// and returns what to install for the given game version and loader.
+func (s *ScraperService) ResolveInstallPlan(opID, sourceID, link, name string, target ResolveTarget) (InstallPlan, error) {
+	src, err := s.sources.get(sourceID)
+	if err != nil {
+		return InstallPlan{}, err
+	}
+	root := ModDependency{Source: src.ID(), ModPageLink: link, Name: name}
+	return operations.Run(s.ops, opID, func(ctx context.Context) (InstallPlan, error) {
+		return ResolveInstallPlan(ctx, s.sources.get, root, target)
+	})
 }
 
-func (s *ScraperService) buildURL(category string, page int, search *string) string {
-	base := fmt.Sprintf("%s/%s/page/%d/", baseURL, category, page)
-	if search != nil && *search != "" {
-		query := strings.ReplaceAll(*search, " ", "+")
-		return fmt.Sprintf("%s?q=%s", base, query)
+// IdentifyModFile asks every source that supports file matching which mod
+// the file at path belongs to. A source failing does not fail the call:
+// its error is reported in the result, next to the matches the other
+// sources found.
+func (s *ScraperService) IdentifyModFile(opID, path string) (FileIdentification, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return FileIdentification{}, fmt.Errorf("failed to read mod file: %w", err)
 	}
-	return base
-}
\ No newline at end of file
+
+	return operations.Run(s.ops, opID, func(ctx context.Context) (FileIdentification, error) {
+		result := FileIdentification{Failures: make(map[string]string)}
+		for _, src := range s.sources.all() {
+			matcher, ok := src.(FileMatcher)
+			if !ok {
+				continue
+			}
+			mod, err := matcher.MatchFile(ctx, data)
+			switch {
+			case err == nil:
+				result.Matches = append(result.Matches, mod)
+			case ctx.Err() != nil:
+				return FileIdentification{}, ctx.Err()
+			case !errors.Is(err, ErrNotFound) && !errors.Is(err, ErrNotConfigured):
+				result.Failures[src.ID()] = err.Error()
+			}
+		}
+		return result, nil
+	})
+}
+
+// GetConnectivity reports whether the sites are currently unreachable, in
+// which case results come from the offline cache.
+func (s *ScraperService) GetConnectivity() nettools.ConnectivityStatus {
+	return nettools.Connectivity()
+}
+
+func (s *ScraperService) GetResourcePacks(opID string, page int, search *string) (ResourcePacksPage, error) {
+	entries, pagination, fresh, err := s.catalogueList(opID, CategoryResourcePacks, page, search)
+	return ResourcePacksPage{Items: convertEntries(entries, toResourcePack), Pagination: pagination, Freshness: fresh}, err
+}
+
+func (s *ScraperService) GetShaderPacks(opID string, page int, search *string) (ShaderPacksPage, error) {
+	entries, pagination, fresh, err := s.catalogueList(opID, CategoryShaders, page, search)
+	return ShaderPacksPage{Items: convertEntries(entries, toShaderPack), Pagination: pagination, Freshness: fresh}, err
+}
+
+func (s *ScraperService) GetMaps(opID string, page int, search *string) (MapsPage, error) {
+	entries, pagination, fresh, err := s.catalogueList(opID, CategoryMaps, page, search)
+	return MapsPage{Items: convertEntries(entries, toMinecraftMap), Pagination: pagination, Freshness: fresh}, err
+}
+
+func (s *ScraperService) GetModpacks(opID string, page int, search *string) (ModpacksPage, error) {
+	entries, pagination, fresh, err := s.catalogueList(opID, CategoryModpacks, page, search)
+	return ModpacksPage{Items: convertEntries(entries, toModpack), Pagination: pagination, Freshness: fresh}, err
+}
+
+func (s *ScraperService) GetResourcePackDetails(opID, link string, versions []string) (ResourcePack, error) {
+	entry, err := s.catalogueDetails(opID, CategoryResourcePacks, link, versions)
+	return toResourcePack(entry), err
+}
+
+func (s *ScraperService) GetShaderPackDetails(opID, link string, versions []string) (ShaderPack, error) {
+	entry, err := s.catalogueDetails(opID, CategoryShaders, link, versions)
+	return toShaderPack(entry), err
+}
+
+func (s *ScraperService) GetMapDetails(opID, link string, versions []string) (MinecraftMap, error) {
+	entry, err := s.catalogueDetails(opID, CategoryMaps, link, versions)
+	return toMinecraftMap(entry), err
+}
+
+func (s *ScraperService) GetModpackDetails(opID, link string, versions []string) (Modpack, error) {
+	entry, err := s.catalogueDetails(opID, CategoryModpacks, link, versions)
+	return toModpack(entry), err
+}
+
+func (s *ScraperService) catalogueList(opID string, category Category, page int, search *string) ([]CatalogueEntry, Pagination, Freshness, error) {
+	type result struct {
+		entries    []CatalogueEntry
+		pagination Pagination
+	}
+
+	page = max(page, 1)
+	r, fresh, err := runTracked(s.ops, opID, func(ctx context.Context) (result, error) {
+		entries, pagination, err := ScrapeCatalogueList(ctx, category, buildURL(string(category), page, search), page)
+		return result{entries, pagination}, err
+	})
+	return r.entries, r.pagination, fresh, err
+}
+
+func (s *ScraperService) catalogueDetails(opID string, category Category, link string, versions []string) (CatalogueEntry, error) {
+	entry := CatalogueEntry{Source: MinecraftInsideID, Category: category, PageLink: link}
+	err := operations.Do(s.ops, opID, func(ctx context.Context) error {
+		return ScrapeCatalogueDetails(ctx, &entry, versions)
+	})
+	return entry, err
+}
+
+func convertEntries[T any](entries []CatalogueEntry, convert func(CatalogueEntry) T) []T {
+	items := make([]T, 0, len(entries))
+	for _, entry := range entries {
+		items = append(items, convert(entry))
+	}
+	return items
+}
@@ -0,0 +1,118 @@
+package parser
+
+import (
+	"slices"
+	"strings"
+)
+
+type SortOrder string
+
+const (
+	SortRelevance SortOrder = ""
+	SortPopular   SortOrder = "popular"
+	SortNewest    SortOrder = "newest"
+	SortUpdated   SortOrder = "updated"
+	SortDownloads SortOrder = "downloads"
+	// SortSize only applies to file lists; sources ignore it in searches.
+	SortSize SortOrder = "size"
+)
+
+// queryFilter names one of the SearchQuery filters, so a source can say
+// which of them it applies server-side.
+type queryFilter int
+
+const (
+	filterVersion queryFilter = 1 << iota
+	filterLoader
+	filterCategory
+)
+
+func (q SearchQuery) hasFilter(f queryFilter) bool {
+	switch f {
+	case filterVersion:
+		return len(q.Versions) > 0
+	case filterLoader:
+		return len(q.Loaders) > 0
+	case filterCategory:
+		return q.Category != ""
+	}
+	return false
+}
+
+// matches reports whether mod passes the filters not covered by serverSide.
+func (q SearchQuery) matches(mod MinecraftMod, serverSide queryFilter) bool {
+	if serverSide&filterVersion == 0 && q.hasFilter(filterVersion) &&
+		!matchesVersions(q.Versions, mod.Versions) {
+		return false
+	}
+	if serverSide&filterLoader == 0 && q.hasFilter(filterLoader) &&
+		!slices.ContainsFunc(mod.Loaders, func(l string) bool { return containsFold(q.Loaders, l) }) {
+		return false
+	}
+	if serverSide&filterCategory == 0 && q.hasFilter(filterCategory) &&
+		!containsFold(mod.Categories, q.Category) {
+		return false
+	}
+	return true
+}
+
+// Matches reports whether mod passes every filter of q, for searching
+// records kept outside a source.
+func (q SearchQuery) Matches(mod MinecraftMod) bool {
+	return q.matches(mod, 0)
+}
+
+// clientSide returns the filters of q that serverSide does not cover.
+func (q SearchQuery) clientSide(serverSide queryFilter) queryFilter {
+	var pending queryFilter
+	for _, f := range []queryFilter{filterVersion, filterLoader, filterCategory} {
+		if serverSide&f == 0 && q.hasFilter(f) {
+			pending |= f
+		}
+	}
+	return pending
+}
+
+func containsFold(values []string, target string) bool {
+	return slices.ContainsFunc(values, func(v string) bool {
+		return strings.EqualFold(strings.TrimSpace(v), strings.TrimSpace(target))
+	})
+}
+
+const (
+	maxFilterScanPages = 10
+	minFilteredResults = 10
+)
+
+// scanFiltered pages through fetch from query.Page on, keeping the mods that
+// pass the filters the source could not apply itself, until enough are
+// collected. The returned Page is the last source page scanned, so the
+// next request should ask for Page+1.
+func scanFiltered(query SearchQuery, serverSide queryFilter, fetch func(page int) (ModsPage, error)) (ModsPage, error) {
+	page := max(query.Page, 1)
+	if query.clientSide(serverSide) == 0 {
+		return fetch(page)
+	}
+
+	result := ModsPage{Items: []MinecraftMod{}}
+	for scanned := 0; scanned < maxFilterScanPages; scanned++ {
+		sourcePage, err := fetch(page)
+		if err != nil {
+			return result, err
+		}
+
+		for _, mod := range sourcePage.Items {
+			if query.matches(mod, serverSide) {
+				result.Items = append(result.Items, mod)
+			}
+		}
+		result.Pagination = sourcePage.Pagination
+		result.TotalResults = 0
+
+		if !sourcePage.HasNext || len(result.Items) >= minFilteredResults {
+			break
+		}
+		page++
+	}
+	return result, nil
+}
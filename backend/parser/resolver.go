@@ -0,0 +1,259 @@
+package parser
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+
+	"github.com/lanxre/mc-launcher/backend/mcversion"
+)
+
+const maxResolveNodes = 200
+
+// ResolveTarget is the game version and loader an install plan is built for.
+type ResolveTarget struct {
+	Version string `yaml:"version"`
+	Loader  string `yaml:"loader"`
+}
+
+type PlannedFile struct {
+	Source      string  `yaml:"source"`
+	Name        string  `yaml:"name"`
+	ModPageLink string  `yaml:"mod_page_link"`
+	File        ModFile `yaml:"file"`
+}
+
+// InstallPlan is everything needed to install a mod. Files is flat and
+// ordered so that dependencies come before the mods needing them. Missing
+// lists mods without a file for the target or whose page failed to load,
+// and Cycles the dependency loops found, each as the page links along the
+// loop.
+type InstallPlan struct {
+	Target  ResolveTarget   `yaml:"target"`
+	Tree    ModDependency   `yaml:"tree"`
+	Files   []PlannedFile   `yaml:"files"`
+	Missing []ModDependency `yaml:"missing"`
+	Cycles  [][]string      `yaml:"cycles"`
+}
+
+type dependencyGraph struct {
+	nodes map[string]*ModDependency
+	edges map[string][]string
+}
+
+// ResolveInstallPlan walks the dependency graph of root breadth-first,
+// resolving each level through the source that owns its mods. A dependency
+// whose page fails is left without files, so it ends up in Missing; only a
+// failure of root itself fails the plan.
+func ResolveInstallPlan(ctx context.Context, sources func(id string) (ModSource, error), root ModDependency, target ResolveTarget) (InstallPlan, error) {
+	graph := dependencyGraph{
+		nodes: make(map[string]*ModDependency),
+		edges: make(map[string][]string),
+	}
+
+	var versions []string
+	if target.Version != "" {
+		versions = []string{target.Version}
+	}
+
+	level := []ModDependency{{Source: root.Source, ModPageLink: root.ModPageLink, Name: root.Name}}
+	graph.nodes[root.ModPageLink] = &ModDependency{Source: root.Source, ModPageLink: root.ModPageLink, Name: root.Name}
+
+	for len(level) > 0 {
+		resolved, failed := resolveLevel(ctx, sources, level, versions)
+		if err := ctx.Err(); err != nil {
+			return InstallPlan{}, err
+		}
+		if err, ok := failed[root.ModPageLink]; ok {
+			return InstallPlan{}, err
+		}
+		for link, err := range failed {
+			log.Printf("⚠️ Failed to resolve dependency %s: %v", link, err)
+		}
+
+		var next []ModDependency
+		for i := range resolved {
+			parent := resolved[i]
+			node := graph.nodes[parent.ModPageLink]
+			node.Details = parent.Details
+
+			for _, child := range parent.Dependency {
+				if child.ModPageLink == "" || child.ModPageLink == parent.ModPageLink {
+					continue
+				}
+				if child.Source == "" {
+					child.Source = parent.Source
+				}
+				if !slices.Contains(graph.edges[parent.ModPageLink], child.ModPageLink) {
+					graph.edges[parent.ModPageLink] = append(graph.edges[parent.ModPageLink], child.ModPageLink)
+				}
+				if _, seen := graph.nodes[child.ModPageLink]; seen {
+					continue
+				}
+				if len(graph.nodes) >= maxResolveNodes {
+					return InstallPlan{}, fmt.Errorf("dependency graph of %s has more than %d mods", root.Name, maxResolveNodes)
+				}
+				node := ModDependency{Source: child.Source, ModPageLink: child.ModPageLink, Name: child.Name}
+				graph.nodes[child.ModPageLink] = &node
+				next = append(next, node)
+			}
+		}
+		level = next
+	}
+
+	return graph.plan(root.ModPageLink, target), nil
+}
+
+// resolveLevel resolves one level of the graph, a batch per source. The
+// errors of mods that could not be resolved are returned by page link.
+func resolveLevel(ctx context.Context, sources func(id string) (ModSource, error), level []ModDependency, versions []string) ([]ModDependency, map[string]error) {
+	bySource := make(map[string][]ModDependency)
+	var order []string
+	for _, dep := range level {
+		if _, ok := bySource[dep.Source]; !ok {
+			order = append(order, dep.Source)
+		}
+		bySource[dep.Source] = append(bySource[dep.Source], dep)
+	}
+
+	var resolved []ModDependency
+	failed := make(map[string]error)
+	for _, id := range order {
+		src, err := sources(id)
+		if err != nil {
+			for _, dep := range bySource[id] {
+				failed[dep.ModPageLink] = err
+			}
+			continue
+		}
+		for _, dep := range resolveBatch(ctx, src, bySource[id], versions, failed) {
+			dep.Source = src.ID()
+			resolved = append(resolved, dep)
+		}
+	}
+	return resolved, failed
+}
+
+// resolveBatch resolves batch through src. If the batch fails, its mods are
+// retried one at a time so a single broken page only loses that mod.
+func resolveBatch(ctx context.Context, src ModSource, batch []ModDependency, versions []string, failed map[string]error) []ModDependency {
+	deps, err := src.ResolveDependencies(ctx, slices.Clone(batch), versions)
+	if err == nil {
+		return deps
+	}
+	if len(batch) == 1 || ctx.Err() != nil {
+		for _, dep := range batch {
+			failed[dep.ModPageLink] = err
+		}
+		return nil
+	}
+
+	deps = nil
+	for _, dep := range batch {
+		one, err := src.ResolveDependencies(ctx, []ModDependency{dep}, versions)
+		if err != nil {
+			failed[dep.ModPageLink] = err
+			continue
+		}
+		deps = append(deps, one...)
+	}
+	return deps
+}
+
+// plan walks the graph depth-first from rootLink. Each mod is built once
+// and shared by every mod depending on it, so diamonds are planned and
+// loops are reported only once.
+func (g dependencyGraph) plan(rootLink string, target ResolveTarget) InstallPlan {
+	plan := InstallPlan{Target: target}
+	built := make(map[string]ModDependency)
+
+	var visit func(link string, path []string) ModDependency
+	visit = func(link string, path []string) ModDependency {
+		if node, ok := built[link]; ok {
+			return node
+		}
+
+		node := *g.nodes[link]
+		node.Dependency = nil
+		path = append(path, link)
+
+		for _, child := range g.edges[link] {
+			if idx := slices.Index(path, child); idx != -1 {
+				plan.Cycles = append(plan.Cycles, append(slices.Clone(path[idx:]), child))
+				continue
+			}
+			node.Dependency = append(node.Dependency, visit(child, path))
+		}
+
+		if file, ok := pickFile(node.Details, target); ok {
+			plan.Files = append(plan.Files, PlannedFile{
+				Source:      node.Source,
+				Name:        node.Name,
+				ModPageLink: node.ModPageLink,
+				File:        file,
+			})
+		} else {
+			plan.Missing = append(plan.Missing, ModDependency{Source: node.Source, ModPageLink: node.ModPageLink, Name: node.Name})
+		}
+		built[link] = node
+		return node
+	}
+
+	plan.Tree = visit(rootLink, nil)
+	return plan
+}
+
+// pickFile returns the newest file built for the target version and
+// loader. Files without loader information are assumed to fit any loader.
+func pickFile(details []ModFile, target ResolveTarget) (ModFile, bool) {
+	var best ModFile
+	found := false
+	for _, d := range details {
+		if target.Version != "" && !matchesVersions([]string{target.Version}, d.Versions) {
+			continue
+		}
+		if target.Loader != "" && len(d.Loaders) > 0 && !containsFold(d.Loaders, target.Loader) {
+			continue
+		}
+		if !found || newerFile(d, best) {
+			best, found = d, true
+		}
+	}
+	return best, found
+}
+
+// newerFile reports whether a is newer than b: published later when both
+// have a date, and otherwise built for a higher game version.
+func newerFile(a, b ModFile) bool {
+	if !a.Published.IsZero() && !b.Published.IsZero() {
+		return a.Published.After(b.Published)
+	}
+	va, okA := highestVersion(a.Versions)
+	vb, okB := highestVersion(b.Versions)
+	if okA && okB {
+		return mcversion.Compare(va, vb) > 0
+	}
+	return okA
+}
+
+// highestVersion returns the newest game version in versions, taking the
+// upper end of ranges.
+func highestVersion(versions []string) (mcversion.Version, bool) {
+	var best mcversion.Version
+	found := false
+	for _, s := range versions {
+		r, err := mcversion.ParseRange(s)
+		if err != nil {
+			continue
+		}
+		v := r.Min.Version
+		if r.Max.Set {
+			v = r.Max.Version
+		}
+		if !found || mcversion.Compare(v, best) > 0 {
+			best, found = v, true
+		}
+	}
+	return best, found
+}
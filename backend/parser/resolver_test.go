@@ -0,0 +1,117 @@
+package parser
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// fakeSource resolves dependencies from a fixed graph. Links in broken
+// fail, and so does every batch holding one of them.
+type fakeSource struct {
+	ModSource
+	graph  map[string][]string
+	broken map[string]bool
+}
+
+func (s fakeSource) ID() string { return "fake" }
+
+func (s fakeSource) ResolveDependencies(ctx context.Context, depends []ModDependency, versions []string) ([]ModDependency, error) {
+	for i, dep := range depends {
+		if s.broken[dep.ModPageLink] {
+			return nil, errors.New("page failed")
+		}
+		depends[i].Details = []ModFile{{FileID: dep.ModPageLink, Versions: []string{"1.20.1"}}}
+		for _, child := range s.graph[dep.ModPageLink] {
+			depends[i].Dependency = append(depends[i].Dependency, ModDependency{ModPageLink: child, Name: child})
+		}
+	}
+	return depends, nil
+}
+
+func TestResolveInstallPlan(t *testing.T) {
+	src := fakeSource{
+		graph: map[string][]string{
+			"a": {"b", "c", "e"},
+			"b": {"d"},
+			"c": {"d"},
+			"d": {"b"},
+		},
+		broken: map[string]bool{"e": true},
+	}
+	sources := func(string) (ModSource, error) { return src, nil }
+	root := ModDependency{Source: "fake", ModPageLink: "a", Name: "a"}
+
+	plan, err := ResolveInstallPlan(context.Background(), sources, root, ResolveTarget{Version: "1.20.1"})
+	if err != nil {
+		t.Fatalf("ResolveInstallPlan: %v", err)
+	}
+
+	var files []string
+	for _, f := range plan.Files {
+		files = append(files, f.ModPageLink)
+	}
+	if want := []string{"d", "b", "c", "a"}; !reflect.DeepEqual(files, want) {
+		t.Errorf("files = %v, want %v", files, want)
+	}
+	if len(plan.Missing) != 1 || plan.Missing[0].ModPageLink != "e" {
+		t.Errorf("missing = %+v, want only e", plan.Missing)
+	}
+	if want := [][]string{{"b", "d", "b"}}; !reflect.DeepEqual(plan.Cycles, want) {
+		t.Errorf("cycles = %v, want %v", plan.Cycles, want)
+	}
+
+	src.broken["a"] = true
+	if _, err := ResolveInstallPlan(context.Background(), sources, root, ResolveTarget{}); err == nil {
+		t.Error("a failing root did not fail the plan")
+	}
+}
+
+func TestPickFile(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, time.March, d, 0, 0, 0, 0, time.UTC) }
+	tests := []struct {
+		name    string
+		details []ModFile
+		target  ResolveTarget
+		want    string
+	}{
+		{
+			name: "newest date",
+			details: []ModFile{
+				{FileID: "old", Versions: []string{"1.20.1"}, Published: day(1)},
+				{FileID: "new", Versions: []string{"1.20.1"}, Published: day(9)},
+				{FileID: "mid", Versions: []string{"1.20.1"}, Published: day(5)},
+			},
+			target: ResolveTarget{Version: "1.20.1"},
+			want:   "new",
+		},
+		{
+			name: "highest version without dates",
+			details: []ModFile{
+				{FileID: "1.18", Versions: []string{"1.18.2"}},
+				{FileID: "1.20", Versions: []string{"1.19.2–1.20.4"}},
+				{FileID: "1.19", Versions: []string{"1.19.4"}},
+			},
+			want: "1.20",
+		},
+		{
+			name: "loader",
+			details: []ModFile{
+				{FileID: "fabric", Loaders: []string{"Fabric"}, Published: day(9)},
+				{FileID: "forge", Loaders: []string{"Forge"}, Published: day(1)},
+			},
+			target: ResolveTarget{Loader: "forge"},
+			want:   "forge",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := pickFile(tt.details, tt.target)
+			if !ok || got.FileID != tt.want {
+				t.Errorf("pickFile = %q, %v, want %q", got.FileID, ok, tt.want)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strings"
@@ -9,28 +10,38 @@ import (
 	"github.com/gocolly/colly/v2"
 )
 
-func ScrapeDependency(depends []ModDependency, versions []string) []ModDependency {
-	c := newDependencyCollector()
+func ScrapeDependency(ctx context.Context, depends []ModDependency, versions []string) ([]ModDependency, error) {
+	c := newCollector(ctx)
+	errs := setupErrorHandler(c)
 
 	results := make(map[string]*ModDependency)
 	mu := &sync.Mutex{}
 
-	setupDependencyHandlers(c, results, mu, versions)
+	setupDependencyHandlers(c, errs, results, mu, versions)
 
+	// The callbacks run while later links are still visited, so results
+	// must be complete before the first Visit.
 	for i := range depends {
 		results[depends[i].ModPageLink] = &depends[i]
-		c.Visit(depends[i].ModPageLink)
+	}
+	for i := range depends {
+		if err := c.Visit(depends[i].ModPageLink); err != nil {
+			errs.addURL(depends[i].ModPageLink, 0, err)
+		}
 	}
 
 	c.Wait()
-	return depends
+	return depends, errs.err()
 }
 
-func ScrapeMinecraftInsideModsFull(url string) ([]MinecraftMod, error) {
+func ScrapeMinecraftInsideModsFull(ctx context.Context, url string, page int) (ModsPage, error) {
 
 	log.Printf("🔍 Scraping mods list: %s", url)
 
-	c := newCollectorWithRetry(3)
+	c := newCollector(ctx)
+	errs := setupErrorHandler(c)
+	setupLayoutCheck(c, errs, "div.box")
+	pagination := setupPaginationHandler(c, page)
 
 	var mods []MinecraftMod
 	c.OnHTML("div.box.box_grass.post", func(e *colly.HTMLElement) {
@@ -41,35 +52,24 @@ func ScrapeMinecraftInsideModsFull(url string) ([]MinecraftMod, error) {
 	})
 
 	if err := c.Visit(url); err != nil {
-		return nil, fmt.Errorf("failed to visit page: %w", err)
+		return ModsPage{}, fmt.Errorf("failed to visit page: %w", err)
 	}
 
 	c.Wait()
 
-	return mods, nil
+	return ModsPage{Items: mods, Pagination: pagination()}, errs.err()
 }
 
-func ScrapeMinecraftPageMod(mod *MinecraftMod) error {
+func ScrapeMinecraftPageMod(ctx context.Context, mod *MinecraftMod) error {
 	if mod.ModPageLink == "" {
 		return fmt.Errorf("mod '%s' has empty link", mod.Name)
 	}
 
-	c := newCollectorWithRetry(3)
+	c := newCollector(ctx)
+	errs := setupErrorHandler(c)
 
 	c.OnHTML("td.dl__info", func(e *colly.HTMLElement) {
-		download := DownloadInfo{
-			URL:       e.Request.AbsoluteURL(e.ChildAttr("a", "href")),
-			Version:   parseVersion(e.ChildText("span.dl__name")),
-			Downloads: parseDownloadCount(e.ChildAttr("span.dl__link", "title")),
-		}
-
-		var loaders []string
-		e.ForEach("span.dl__loader", func(_ int, el *colly.HTMLElement) {
-			loaders = append(loaders, strings.TrimSpace(el.Text))
-		})
-		download.Loader = strings.Join(loaders, ", ")
-
-		if download.URL != "" {
+		if download := parseDownloadRow(e); download.URL != "" {
 			mod.Details = append(mod.Details, download)
 		}
 	})
@@ -84,7 +84,7 @@ func ScrapeMinecraftPageMod(mod *MinecraftMod) error {
 	}
 
 	c.Wait()
-	return nil
+	return errs.err()
 }
 
 func ParseModBlock(e *colly.HTMLElement) MinecraftMod {
@@ -96,5 +96,7 @@ func ParseModBlock(e *colly.HTMLElement) MinecraftMod {
 		ModPageLink: e.Request.AbsoluteURL(e.ChildAttr("h2.box__title a", "href")),
 		Icon:        e.Request.AbsoluteURL(e.ChildAttr("a.post__cover img", "src")),
 		Description: cleanDescription(e.ChildText("div.box__body > div:first-child"), name),
+		Downloads:   postDownloads(e.DOM),
+		Updated:     parseDate(itemValue(e.DOM, postDateSelector)),
 	}
 }
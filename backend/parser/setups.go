@@ -1,87 +1,100 @@
 package parser
 
 import (
-	"encoding/json"
 	"fmt"
 	"regexp"
 	"slices"
+	"strconv"
 	"strings"
 	"sync"
 
+	"github.com/PuerkitoBio/goquery"
 	"github.com/gocolly/colly/v2"
 )
 
-func setupDependencyHandlers(c *colly.Collector, results map[string]*ModDependency, mu *sync.Mutex, versions []string) {
-	setupDependencyDetailsHandler(c, results, mu, versions)
+var (
+	pageNumberPattern   = regexp.MustCompile(`/page/(\d+)/`)
+	totalResultsPattern = regexp.MustCompile(`(?i)найдено\D{0,40}?(\d[\d \x{00a0}]*)`)
+)
+
+// setupPaginationHandler reads the page links of a list page. The total
+// number of results is only shown on search pages.
+func setupPaginationHandler(c *colly.Collector, page int) func() Pagination {
+	pagination := Pagination{Page: page}
+
+	c.OnHTML(".pagination", func(e *colly.HTMLElement) {
+		e.ForEach("a[href]", func(_ int, a *colly.HTMLElement) {
+			if m := pageNumberPattern.FindStringSubmatch(a.Attr("href")); m != nil {
+				n, _ := strconv.Atoi(m[1])
+				pagination.TotalPages = max(pagination.TotalPages, n)
+			}
+			if n, err := strconv.Atoi(strings.TrimSpace(a.Text)); err == nil {
+				pagination.TotalPages = max(pagination.TotalPages, n)
+			}
+		})
+	})
+
+	c.OnHTML("body", func(e *colly.HTMLElement) {
+		m := totalResultsPattern.FindStringSubmatch(e.DOM.Find("h1, .search__info, .box__title").Text())
+		if m == nil {
+			return
+		}
+		digits := strings.Map(func(r rune) rune {
+			if r >= '0' && r <= '9' {
+				return r
+			}
+			return -1
+		}, m[1])
+		pagination.TotalResults, _ = strconv.Atoi(digits)
+	})
+
+	return func() Pagination {
+		pagination.TotalPages = max(pagination.TotalPages, pagination.Page)
+		pagination.HasNext = pagination.Page < pagination.TotalPages
+		return pagination
+	}
+}
+
+func setupDependencyHandlers(c *colly.Collector, errs *scrapeErrors, results map[string]*ModDependency, mu *sync.Mutex, versions []string) {
+	setupDependencyDetailsHandler(c, errs, results, mu, versions)
 	setupSubDependenciesHandler(c, results, mu)
 }
 
-func setupDependencyDetailsHandler(c *colly.Collector, results map[string]*ModDependency, mu *sync.Mutex, versions []string) {
-	var details []DownloadInfo
+// setupLayoutCheck reports ErrLayoutChanged for pages that lack selector,
+// the element every page of that kind is expected to have.
+func setupLayoutCheck(c *colly.Collector, errs *scrapeErrors, selector string) {
+	c.OnHTML("html", func(e *colly.HTMLElement) {
+		if e.DOM.Find(selector).Length() == 0 && !isChallenge(e.Response.Body) {
+			errs.addURL(e.Request.URL.String(), e.Response.StatusCode, ErrLayoutChanged)
+		}
+	})
+}
+
+func setupDependencyDetailsHandler(c *colly.Collector, errs *scrapeErrors, results map[string]*ModDependency, mu *sync.Mutex, versions []string) {
 	c.OnHTML("script", func(e *colly.HTMLElement) {
 		parentURL := e.Request.URL.String()
 		script := e.Text
-		if !strings.Contains(script, "var dbox_data =") {
+		if !strings.Contains(script, dboxMarker) {
 			return
 		}
 
-		re := regexp.MustCompile(`files\s*:\s*(\[\s*{[\s\S]*?\}\s*\])`)
-		matches := re.FindStringSubmatch(script)
-		if len(matches) < 2 {
+		files, err := decodeDboxFiles(script)
+		if err != nil {
+			errs.addURL(e.Request.URL.String(), e.Response.StatusCode, err)
 			return
 		}
 
-		jsonRaw := matches[1]
-
-		cleaner := regexp.MustCompile(`</?span[^>]*>`)
-		jsonRaw = cleaner.ReplaceAllString(jsonRaw, "")
-		jsonRaw = strings.ReplaceAll(jsonRaw, "\\\"", "\"")
-		jsonRaw = strings.ReplaceAll(jsonRaw, "\\", "")
-
-		var files []map[string]any
-		if err := json.Unmarshal([]byte(jsonRaw), &files); err != nil {
+		mu.Lock()
+		defer mu.Unlock()
+		mod, ok := results[parentURL]
+		if !ok {
 			return
 		}
-
-		details = details[:0]
-
 		for _, f := range files {
-			name := f["name"].(string)
-			fileID := f["id"].(string)
-			downloads := getString(f, "downloads")
-			
-			var loader string
-			if f["loaders"] != nil {
-				loaders := f["loaders"].([]any)
-				loader = loaders[0].(string)
-			} else {
-				loader = "forge"
-			}
-
-			parsedVerson := parseVersion(name)
-			parsedSplitVersion := strings.Split(parsedVerson, ",")
-			isFound := false
-
-			for _, pVersion := range parsedSplitVersion {
-				for _, version := range versions {
-					if version == pVersion {
-						isFound = true
-					}
-				}
-			}
-			if mod, ok := results[parentURL]; ok && isFound {
-				mu.Lock()
-				mod.Details = append(mod.Details, DownloadInfo{
-					URL:       "https://minecraft-inside.ru/download/" + fileID + "/",
-					Version:   parsedVerson,
-					Loader:    loader,
-					Downloads: downloads,
-				})
-				mu.Unlock()
+			if file := minecraftInsideFile(f); matchesVersions(versions, file.Versions) {
+				mod.Details = append(mod.Details, file)
 			}
 		}
-		
-
 	})
 }
 
@@ -123,6 +136,75 @@ func setupSubDependenciesHandler(c *colly.Collector, results map[string]*ModDepe
 	})
 }
 
+// Selectors of the mod page metadata. Dates and rating come from the
+// schema.org microdata and Open Graph tags the page carries.
+const (
+	modBodySelector   = "div.box__body"
+	modAuthorSelector = "[itemprop=author] [itemprop=name], [itemprop=author], a[rel=author], .post__author"
+	modTagSelector    = "a[rel=tag], .post__tags a, .box__tags a"
+	modRatingSelector = "[itemprop=ratingValue]"
+)
+
+// Selectors of the update date and download counter a post shows, both in
+// the mod list and on the mod page.
+const (
+	postDateSelector      = "[itemprop=dateModified], time[datetime], .post__date"
+	postDownloadsSelector = ".post__downloads, .post__info [title^='Скачиваний:']"
+)
+
+// postDownloads reads the download counter of a post from its
+// "Скачиваний: N" title or, failing that, its text.
+func postDownloads(doc *goquery.Selection) int64 {
+	s := doc.Find(postDownloadsSelector).First()
+	if n := parseCount(parseDownloadCount(s.AttrOr("title", ""))); n > 0 {
+		return n
+	}
+	return parseCount(strings.TrimSpace(s.Text()))
+}
+
+// setupModInfoHandler reads the name, metadata and full description of a
+// mod page.
+func setupModInfoHandler(c *colly.Collector) func() MinecraftMod {
+	var mod MinecraftMod
+
+	c.OnHTML("html", func(e *colly.HTMLElement) {
+		doc := e.DOM
+		name, versions := nameParser(strings.TrimSpace(doc.Find("h1").First().Text()), "[")
+		mod.Name = name
+		mod.Versions = nameVersionParse(versions)
+		mod.Description = strings.TrimSpace(doc.Find("meta[name=description]").AttrOr("content", ""))
+		mod.Author = strings.TrimSpace(doc.Find(modAuthorSelector).First().Text())
+		mod.Published = parseDate(itemValue(doc, "[itemprop=datePublished]", "meta[property='article:published_time']"))
+		mod.Updated = parseDate(itemValue(doc, "[itemprop=dateModified]", "meta[property='article:modified_time']"))
+		mod.Rating = parseRating(itemValue(doc, modRatingSelector))
+		if mod.Updated.IsZero() {
+			mod.Updated = parseDate(itemValue(doc, postDateSelector))
+		}
+
+		// Without a counter on the page, the mod's downloads are those of
+		// all its files.
+		if mod.Downloads = postDownloads(doc); mod.Downloads == 0 {
+			doc.Find("td.dl__info span.dl__link").Each(func(_ int, s *goquery.Selection) {
+				mod.Downloads += parseCount(parseDownloadCount(s.AttrOr("title", "")))
+			})
+		}
+
+		doc.Find(modTagSelector).Each(func(_ int, s *goquery.Selection) {
+			if tag := strings.TrimSpace(s.Text()); tag != "" && !slices.Contains(mod.Categories, tag) {
+				mod.Categories = append(mod.Categories, tag)
+			}
+		})
+
+		body := doc.Find(modBodySelector).First()
+		mod.Body = htmlToMarkdown(body, e.Request.URL)
+		body.Find("a[href]").Each(func(_ int, s *goquery.Selection) {
+			mod.Links.add(e.Request.AbsoluteURL(s.AttrOr("href", "")))
+		})
+	})
+
+	return func() MinecraftMod { return mod }
+}
+
 func setupScreenshotHandler(c *colly.Collector) func() []string {
 	var screenshots []string
 
@@ -137,89 +219,84 @@ func setupScreenshotHandler(c *colly.Collector) func() []string {
 	return func() []string { return screenshots }
 }
 
-func setupDetailsHandler(c *colly.Collector, versions []string) func() []DownloadInfo {
-	var details []DownloadInfo
+func setupDetailsHandler(c *colly.Collector, versions []string) func() []ModFile {
+	var details []ModFile
 
 	c.OnHTML("td.dl__info", func(e *colly.HTMLElement) {
-
-		parsedVersion := parseVersion(e.ChildText("span.dl__name"))
-		if !slices.Contains(versions, parsedVersion) {
-			return
-		}
-
-		download := DownloadInfo{
-			URL:       e.Request.AbsoluteURL(e.ChildAttr("a", "href")),
-			Version:   parsedVersion,
-			Downloads: parseDownloadCount(e.ChildAttr("span.dl__link", "title")),
-		}
-
-		var loaders []string
-		e.ForEach("span.dl__loader", func(_ int, el *colly.HTMLElement) {
-			loaders = append(loaders, strings.TrimSpace(el.Text))
-		})
-		download.Loader = strings.Join(loaders, ", ")
-
-		if download.URL != "" {
+		download := parseDownloadRow(e)
+		if download.URL != "" && matchesVersions(versions, download.Versions) {
 			details = append(details, download)
 		}
 	})
 
-	return func() []DownloadInfo { return details }
+	return func() []ModFile { return details }
+}
+
+// parseDownloadRow reads one row of the download table on a mod page.
+func parseDownloadRow(e *colly.HTMLElement) ModFile {
+	download := ModFile{
+		Source:    MinecraftInsideID,
+		URL:       e.Request.AbsoluteURL(e.ChildAttr("a", "href")),
+		Versions:  parseVersions(e.ChildText("span.dl__name")),
+		Downloads: parseDownloadCount(e.ChildAttr("span.dl__link", "title")),
+	}
+	download.FileID = minecraftInsideFileID(download.URL)
+	download.DownloadCount = parseCount(download.Downloads)
+
+	e.ForEach("span.dl__loader", func(_ int, el *colly.HTMLElement) {
+		download.Loaders = append(download.Loaders, strings.TrimSpace(el.Text))
+	})
+	return download
 }
 
-func setupMinecraftModDetails(c *colly.Collector) func() []MinecraftModDetails {
-	var details []MinecraftModDetails
+func setupMinecraftModDetails(c *colly.Collector, errs *scrapeErrors) func() []ModFile {
+	var details []ModFile
 
 	c.OnHTML("script", func(e *colly.HTMLElement) {
 		script := e.Text
-		if !strings.Contains(script, "var dbox_data =") {
+		if !strings.Contains(script, dboxMarker) {
 			return
 		}
 
-		re := regexp.MustCompile(`files\s*:\s*(\[\s*{[\s\S]*?\}\s*\])`)
-		matches := re.FindStringSubmatch(script)
-		if len(matches) < 2 {
-			return
-		}
-
-		jsonRaw := matches[1]
-
-		cleaner := regexp.MustCompile(`</?span[^>]*>`)
-		jsonRaw = cleaner.ReplaceAllString(jsonRaw, "")
-		jsonRaw = strings.ReplaceAll(jsonRaw, "\\\"", "\"")
-		jsonRaw = strings.ReplaceAll(jsonRaw, "\\", "")
-
-		var files []map[string]any
-		if err := json.Unmarshal([]byte(jsonRaw), &files); err != nil {
+		files, err := decodeDboxFiles(script)
+		if err != nil {
+			errs.addURL(e.Request.URL.String(), e.Response.StatusCode, err)
 			return
 		}
 
 		details = details[:0]
 
 		for _, f := range files {
-			name := f["name"].(string)
-			fileID := f["id"].(string)
-			size := getString(f, "size")
-			date := getString(f, "created")
-			downloads := getString(f, "downloads")
-
-			loaders := f["loaders"].([]interface{})
-			loader := loaders[0].(string)
-
-			details = append(details, MinecraftModDetails{
-				FileID:      fileID,
-				Version:     parseVersion(name),
-				Loader:      loader,
-				Date:        date,
-				Size:        size,
-				Downloads:   downloads,
-				DownloadURL: "https://minecraft-inside.ru/download/" + fileID + "/",
-			})
+			details = append(details, minecraftInsideFile(f))
 		}
 
 	})
 
-	return func() []MinecraftModDetails { return details }
+	return func() []ModFile { return details }
+}
+
+// minecraftInsideFile converts one entry of the dbox_data files list.
+// Entries without loaders are Forge builds.
+func minecraftInsideFile(f dboxFile) ModFile {
+	fileID := string(f.ID)
+	file := ModFile{
+		Source:    MinecraftInsideID,
+		FileID:    fileID,
+		Versions:  parseVersions(string(f.Name)),
+		Loaders:   f.Loaders,
+		URL:       "https://minecraft-inside.ru/download/" + fileID + "/",
+		Size:      string(f.Size),
+		Date:      string(f.Created),
+		Downloads: string(f.Downloads),
+		Changelog: htmlFragmentToMarkdown(string(f.Changelog)),
+	}
+	if len(file.Loaders) == 0 {
+		file.Loaders = []string{"forge"}
+	}
+	file.SizeBytes = parseSize(file.Size)
+	file.Published = parseDate(file.Date)
+	file.DownloadCount = parseCount(file.Downloads)
+	return file
 }
 
 func setupDependenciesHandler(c *colly.Collector) func() []ModDependency {
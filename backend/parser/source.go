@@ -0,0 +1,106 @@
+package parser
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// ModSource is a catalogue of mods that ScraperService can browse.
+type ModSource interface {
+	ID() string
+	Name() string
+	Search(ctx context.Context, query SearchQuery) (ModsPage, error)
+	ListPage(ctx context.Context, page int) (ModsPage, error)
+	GetDetails(ctx context.Context, link string, versions []string) (MinecraftMod, error)
+	GetFiles(ctx context.Context, link string) ([]ModFile, error)
+	ResolveDependencies(ctx context.Context, depends []ModDependency, versions []string) ([]ModDependency, error)
+}
+
+// FileMatcher is implemented by sources that can identify a local mod file
+// from its contents.
+type FileMatcher interface {
+	MatchFile(ctx context.Context, data []byte) (MinecraftMod, error)
+}
+
+// ChangelogSource is implemented by sources that serve file changelogs
+// separately from the file list, leaving ModFile.Changelog empty there.
+type ChangelogSource interface {
+	FileChangelog(ctx context.Context, link string, file ModFile) (string, error)
+}
+
+// SearchQuery is a structured catalogue query. Versions and Loaders match
+// any of the listed values; an empty field does not filter.
+type SearchQuery struct {
+	Text     string    `yaml:"text"`
+	Page     int       `yaml:"page"`
+	Versions []string  `yaml:"versions"`
+	Loaders  []string  `yaml:"loaders"`
+	Category string    `yaml:"category"`
+	Sort     SortOrder `yaml:"sort"`
+}
+
+type SourceInfo struct {
+	ID   string `yaml:"id"`
+	Name string `yaml:"name"`
+}
+
+type sourceRegistry struct {
+	mu        sync.RWMutex
+	sources   map[string]ModSource
+	order     []string
+	defaultID string
+}
+
+func newSourceRegistry() *sourceRegistry {
+	return &sourceRegistry{sources: make(map[string]ModSource)}
+}
+
+func (r *sourceRegistry) register(src ModSource) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.sources[src.ID()]; !ok {
+		r.order = append(r.order, src.ID())
+	}
+	r.sources[src.ID()] = src
+	if r.defaultID == "" {
+		r.defaultID = src.ID()
+	}
+}
+
+func (r *sourceRegistry) get(id string) (ModSource, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if id == "" {
+		id = r.defaultID
+	}
+	src, ok := r.sources[id]
+	if !ok {
+		return nil, fmt.Errorf("unknown mod source %q", id)
+	}
+	return src, nil
+}
+
+func (r *sourceRegistry) all() []ModSource {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	sources := make([]ModSource, 0, len(r.order))
+	for _, id := range r.order {
+		sources = append(sources, r.sources[id])
+	}
+	return sources
+}
+
+func (r *sourceRegistry) list() []SourceInfo {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	infos := make([]SourceInfo, 0, len(r.order))
+	for _, id := range r.order {
+		infos = append(infos, SourceInfo{ID: id, Name: r.sources[id].Name()})
+	}
+	return infos
+}
@@ -1,20 +1,53 @@
 package parser
 
+import "time"
+
 type ModDependency struct {
+	Source      string          `yaml:"source"`
 	ModPageLink string          `yaml:"mod_page_link"`
 	Name        string          `yaml:"name"`
 	Dependency  []ModDependency `yaml:"dependencies"`
-	Details     []DownloadInfo  `yaml:"details"`
+	Details     []ModFile       `yaml:"details"`
+}
+
+// ModFile is one downloadable file of a mod, whichever source it comes
+// from. Size, Date and Downloads are the source's display strings, with
+// SizeBytes, Published and DownloadCount holding them parsed. Hashes maps
+// an algorithm name such as "sha1" to the hex digest, when known. Changelog
+// is Markdown and may be empty when the source serves it separately.
+// ManualURL is set instead of URL for files the author only lets users
+// download from the source's own page.
+type ModFile struct {
+	Source        string            `yaml:"source"`
+	FileID        string            `yaml:"file_id"`
+	FileName      string            `yaml:"file_name"`
+	Versions      []string          `yaml:"versions"`
+	Loaders       []string          `yaml:"loaders"`
+	URL           string            `yaml:"url"`
+	ManualURL     string            `yaml:"manual_url,omitempty"`
+	Size          string            `yaml:"size"`
+	SizeBytes     int64             `yaml:"size_bytes"`
+	Date          string            `yaml:"date"`
+	Published     time.Time         `yaml:"published"`
+	Downloads     string            `yaml:"downloads"`
+	DownloadCount int64             `yaml:"download_count"`
+	Hashes        map[string]string `yaml:"hashes,omitempty"`
+	Changelog     string            `yaml:"changelog"`
 }
 
-type DownloadInfo struct {
-	URL       string `yaml:"url"`
-	Version   string `yaml:"version"`
-	Loader    string `yaml:"loader"`
-	Downloads string `yaml:"downloads"`
+// ModLinks are the external pages of a mod, when the source names them.
+type ModLinks struct {
+	Source  string `yaml:"source"`
+	Issues  string `yaml:"issues"`
+	Wiki    string `yaml:"wiki"`
+	Discord string `yaml:"discord"`
 }
 
+// MinecraftMod is a mod as listed or, with the fields after Updated filled
+// in, as shown on its details page. Body is the full description as
+// sanitised Markdown.
 type MinecraftMod struct {
+	Source      string          `yaml:"source"`
 	Name        string          `yaml:"name"`
 	Icon        string          `yaml:"icon"`
 	ModPageLink string          `yaml:"mod_page_link"`
@@ -22,16 +55,65 @@ type MinecraftMod struct {
 	Versions    []string        `yaml:"versions"`
 	Screenshots []string        `yaml:"screenshots"`
 	Loaders     []string        `yaml:"loaders"`
+	Categories  []string        `yaml:"categories"`
+	Downloads   int64           `yaml:"downloads"`
+	Updated     time.Time       `yaml:"updated"`
+	Author      string          `yaml:"author"`
+	Published   time.Time       `yaml:"published"`
+	Rating      float64         `yaml:"rating"`
+	Links       ModLinks        `yaml:"links"`
+	Body        string          `yaml:"body"`
 	Dependency  []ModDependency `yaml:"dependencies"`
-	Details     []DownloadInfo  `yaml:"details"`
+	Details     []ModFile       `yaml:"details"`
+	Freshness   Freshness       `yaml:"freshness"`
+}
+
+// Pagination describes where a page of results sits in the whole list.
+// TotalResults is 0 when the source does not report it.
+type Pagination struct {
+	Page         int  `yaml:"page"`
+	TotalPages   int  `yaml:"total_pages"`
+	HasNext      bool `yaml:"has_next"`
+	TotalResults int  `yaml:"total_results"`
+}
+
+type ModsPage struct {
+	Items      []MinecraftMod `yaml:"items"`
+	Pagination `yaml:",inline"`
+	Freshness  `yaml:",inline"`
+}
+
+// ModFileList is the file list of a mod, marked stale when it was read from
+// the offline cache.
+type ModFileList struct {
+	Items     []ModFile `yaml:"items"`
+	Freshness `yaml:",inline"`
+}
+
+// DependencyList holds resolved dependencies, marked stale when their pages
+// were read from the offline cache.
+type DependencyList struct {
+	Items     []ModDependency `yaml:"items"`
+	Freshness `yaml:",inline"`
+}
+
+// FileIdentification is what the sources found out about a local mod file.
+// Failures holds the error of every source that could not answer, by
+// source ID; sources that do not know the file or are not configured are
+// left out.
+type FileIdentification struct {
+	Matches  []MinecraftMod    `yaml:"matches"`
+	Failures map[string]string `yaml:"failures"`
 }
 
-type MinecraftModDetails struct {
-	FileID      string `json:"file_id"`
-	Version     string `json:"minecraft"`
-	Loader      string `json:"loader"`
-	Date        string `json:"date"`
-	Size        string `json:"size"`
-	Downloads   string `json:"downloads"`
-	DownloadURL string `json:"download_url"`
+// NewPagination describes page of a result list split into pages of
+// pageSize items.
+func NewPagination(page, pageSize, totalResults int) Pagination {
+	totalPages := (totalResults + pageSize - 1) / pageSize
+	return Pagination{
+		Page:         page,
+		TotalPages:   totalPages,
+		HasNext:      page < totalPages,
+		TotalResults: totalResults,
+	}
 }
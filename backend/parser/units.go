@@ -0,0 +1,133 @@
+package parser
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// moscow is the time zone minecraft-inside.ru prints its dates in.
+var moscow = time.FixedZone("MSK", 3*60*60)
+
+var (
+	countPattern     = regexp.MustCompile(`^([\d.,]+)\s*(тыс|млн|k|m)?`)
+	sizePattern      = regexp.MustCompile(`^([\d.,]+)\s*([a-zа-яё]*)`)
+	clockPattern     = regexp.MustCompile(`(\d{1,2}):(\d{2})`)
+	numericDate      = regexp.MustCompile(`^(\d{1,2})\.(\d{1,2})\.(\d{4})`)
+	longDatePattern  = regexp.MustCompile(`^(\d{1,2})\s+([а-яё]+)\.?\s+(\d{4})`)
+	spaceReplacer    = strings.NewReplacer(" ", "", " ", "", " ", "", " ", "")
+	russianMonthStem = []string{"янв", "фев", "мар", "апр", "ма", "июн", "июл", "авг", "сен", "окт", "ноя", "дек"}
+)
+
+var sizeUnits = map[string]int64{
+	"": 1, "b": 1, "б": 1, "байт": 1, "байта": 1, "bytes": 1,
+	"kb": 1 << 10, "kib": 1 << 10, "кб": 1 << 10, "к": 1 << 10,
+	"mb": 1 << 20, "mib": 1 << 20, "мб": 1 << 20, "м": 1 << 20,
+	"gb": 1 << 30, "gib": 1 << 30, "гб": 1 << 30,
+}
+
+// parseCount reads download counters such as "12 345", "12,3 тыс." or
+// "1.2M". It returns 0 when text holds no number.
+func parseCount(text string) int64 {
+	m := countPattern.FindStringSubmatch(strings.ToLower(spaceReplacer.Replace(text)))
+	if m == nil {
+		return 0
+	}
+
+	if m[2] == "" {
+		n, _ := strconv.ParseInt(strings.NewReplacer(",", "", ".", "").Replace(m[1]), 10, 64)
+		return n
+	}
+
+	n, err := strconv.ParseFloat(strings.ReplaceAll(m[1], ",", "."), 64)
+	if err != nil {
+		return 0
+	}
+	switch m[2] {
+	case "тыс", "k":
+		n *= 1e3
+	case "млн", "m":
+		n *= 1e6
+	}
+	return int64(n)
+}
+
+// parseSize reads file sizes such as "1,5 МБ", "512 KB" or a plain byte
+// count. It returns 0 for unknown units.
+func parseSize(text string) int64 {
+	m := sizePattern.FindStringSubmatch(strings.ToLower(spaceReplacer.Replace(text)))
+	if m == nil {
+		return 0
+	}
+	unit, ok := sizeUnits[m[2]]
+	if !ok {
+		return 0
+	}
+	n, err := strconv.ParseFloat(strings.ReplaceAll(m[1], ",", "."), 64)
+	if err != nil {
+		return 0
+	}
+	return int64(n * float64(unit))
+}
+
+// parseDate reads the dates the sources use: RFC 3339 from the APIs, unix
+// timestamps, and the site's "12.03.2024", "12 марта 2024 в 14:05",
+// "сегодня в 14:05" or "вчера". It returns the zero time when text does
+// not match any of them.
+func parseDate(text string) time.Time {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return time.Time{}
+	}
+
+	if t, err := time.Parse(time.RFC3339Nano, text); err == nil {
+		return t
+	}
+	text = strings.ToLower(text)
+	if unix, err := strconv.ParseInt(text, 10, 64); err == nil && unix > 0 {
+		return time.Unix(unix, 0)
+	}
+
+	var year, month, day int
+	now := time.Now().In(moscow)
+	switch {
+	case strings.HasPrefix(text, "сегодня"):
+		year, month, day = now.Year(), int(now.Month()), now.Day()
+	case strings.HasPrefix(text, "вчера"):
+		y := now.AddDate(0, 0, -1)
+		year, month, day = y.Year(), int(y.Month()), y.Day()
+	default:
+		if m := numericDate.FindStringSubmatch(text); m != nil {
+			day, _ = strconv.Atoi(m[1])
+			month, _ = strconv.Atoi(m[2])
+			year, _ = strconv.Atoi(m[3])
+		} else if m := longDatePattern.FindStringSubmatch(text); m != nil {
+			day, _ = strconv.Atoi(m[1])
+			month = russianMonth(m[2])
+			year, _ = strconv.Atoi(m[3])
+		}
+	}
+	if month < 1 || month > 12 || day < 1 {
+		return time.Time{}
+	}
+
+	var hour, minute int
+	if m := clockPattern.FindStringSubmatch(text); m != nil {
+		hour, _ = strconv.Atoi(m[1])
+		minute, _ = strconv.Atoi(m[2])
+	}
+	return time.Date(year, time.Month(month), day, hour, minute, 0, 0, moscow)
+}
+
+// russianMonth returns the month number for a full, genitive or shortened
+// Russian month name, or 0.
+func russianMonth(name string) int {
+	for i, stem := range russianMonthStem {
+		if strings.HasPrefix(name, stem) {
+			// "ма" would also catch "март"; March is checked first.
+			return i + 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,106 @@
+package parser
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestParseCount(t *testing.T) {
+	tests := map[string]int64{
+		"":             0,
+		"нет":          0,
+		"15":           15,
+		"12 345":       12345,
+		"12\u00a0345":  12345,
+		"1,234,567":    1234567,
+		"12,3 тыс.":    12300,
+		"3,4 тыс.":     3400,
+		"1.2M":         1200000,
+		"2 млн":        2000000,
+		"5k":           5000,
+		"Скачиваний 5": 0,
+	}
+	for text, want := range tests {
+		if got := parseCount(text); got != want {
+			t.Errorf("parseCount(%q) = %d, want %d", text, got, want)
+		}
+	}
+}
+
+func TestParseSize(t *testing.T) {
+	tests := map[string]int64{
+		"":         0,
+		"1024":     1024,
+		"512 KB":   512 << 10,
+		"980 КБ":   980 << 10,
+		"1,5 МБ":   3 << 19,
+		"1.5 MB":   3 << 19,
+		"2 GiB":    2 << 30,
+		"10 байт":  10,
+		"3 парсек": 0,
+	}
+	for text, want := range tests {
+		if got := parseSize(text); got != want {
+			t.Errorf("parseSize(%q) = %d, want %d", text, got, want)
+		}
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	tests := map[string]time.Time{
+		"":                      {},
+		"недавно":               {},
+		"2024-03-12T14:05:00Z":  time.Date(2024, time.March, 12, 14, 5, 0, 0, time.UTC),
+		"1700000000":            time.Unix(1700000000, 0),
+		"12.03.2024":            time.Date(2024, time.March, 12, 0, 0, 0, 0, moscow),
+		"12.03.2024 в 14:05":    time.Date(2024, time.March, 12, 14, 5, 0, 0, moscow),
+		"1 февраля 2024":        time.Date(2024, time.February, 1, 0, 0, 0, 0, moscow),
+		"12 марта 2024 в 14:05": time.Date(2024, time.March, 12, 14, 5, 0, 0, moscow),
+		"3 мая 2023":            time.Date(2023, time.May, 3, 0, 0, 0, 0, moscow),
+		"7 дек. 2022":           time.Date(2022, time.December, 7, 0, 0, 0, 0, moscow),
+		"31.13.2024":            {},
+	}
+	for text, want := range tests {
+		if got := parseDate(text); !got.Equal(want) {
+			t.Errorf("parseDate(%q) = %v, want %v", text, got, want)
+		}
+	}
+
+	now := time.Now().In(moscow)
+	today := parseDate("сегодня в 09:30")
+	if today.Year() != now.Year() || today.YearDay() != now.YearDay() || today.Hour() != 9 || today.Minute() != 30 {
+		t.Errorf("parseDate(сегодня в 09:30) = %v", today)
+	}
+	yesterday := now.AddDate(0, 0, -1)
+	if got := parseDate("вчера"); got.YearDay() != yesterday.YearDay() {
+		t.Errorf("parseDate(вчера) = %v, want day %d", got, yesterday.YearDay())
+	}
+}
+
+func TestParseVersions(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"", nil},
+		{"Для 1.20.1", []string{"1.20.1"}},
+		{"Для 1.20.1 - 1.20.4", []string{"1.20.1–1.20.4"}},
+		{"Для 1.19–1.20.4", []string{"1.19–1.20.4"}},
+		{"Для 1.20.1, 1.20.4", []string{"1.20.1", "1.20.4"}},
+		{"Для 1.20.1, 1.20.2, 1.20.4", []string{"1.20.1", "1.20.2", "1.20.4"}},
+		{"Для 1.20.1, 1.20.2 и 1.20.4", []string{"1.20.1", "1.20.2", "1.20.4"}},
+		{"Для 1.16.5 - 1.18.2, 1.19", []string{"1.16.5–1.18.2", "1.19"}},
+		{"Для 1.20.x", []string{"1.20.x"}},
+		{"Для 1.21-pre1", []string{"1.21-pre1"}},
+		{"Для 24w14a", []string{"24w14a"}},
+		{"Для 1.20.1 Fabric", []string{"1.20.1"}},
+		{"Для 1.12.2 <span>(Forge)</span>", []string{"1.12.2"}},
+		{"Для Bedrock", []string{"Bedrock"}},
+	}
+	for _, tt := range tests {
+		if got := parseVersions(tt.text); !slices.Equal(got, tt.want) {
+			t.Errorf("parseVersions(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
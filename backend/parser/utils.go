@@ -1,8 +1,14 @@
 package parser
 
 import (
-	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/lanxre/mc-launcher/backend/mcversion"
 )
 
 func processScreenshots(urls []string) []string {
@@ -66,23 +72,78 @@ func nameVersionParse(versions string) []string {
 	return result
 }
 
-func parseVersion(text string) string {
+// versionListSeparator splits the version lists of file names such as
+// "Для 1.20.1, 1.20.2 и 1.20.4".
+var versionListSeparator = regexp.MustCompile(`\s*[,;]\s*|\s+и\s+`)
+
+// parseVersions turns a file name such as "Для 1.20.1 - 1.20.4" or
+// "Для 1.20.1, 1.20.4" into the canonical versions or version ranges it
+// covers.
+func parseVersions(text string) []string {
 	text = strings.TrimSpace(text)
 	if idx := strings.Index(text, "<span"); idx != -1 {
 		text = strings.TrimSpace(text[:idx])
 	}
-
 	text = strings.TrimPrefix(text, "Для ")
 
-	versText := strings.Split(text, " ")
+	var versions []string
+	for _, part := range versionListSeparator.Split(text, -1) {
+		if part = strings.TrimSpace(part); part != "" {
+			versions = append(versions, parseVersionEntry(part))
+		}
+	}
+	return versions
+}
 
-	if len(versText) > 2 {
-		text = fmt.Sprintf("%s, %s", versText[0], versText[2])
-	} else {
-		text = versText[0]
+// parseVersionEntry returns the canonical form of one entry of a version
+// list, ignoring words after the version such as a loader name. Entries
+// that do not parse are kept as they are.
+func parseVersionEntry(entry string) string {
+	if r, err := mcversion.ParseRange(entry); err == nil {
+		return r.String()
+	}
+	if fields := strings.Fields(entry); len(fields) > 1 {
+		if r, err := mcversion.ParseRange(fields[0]); err == nil {
+			return r.String()
+		}
 	}
+	return entry
+}
 
-	return text
+// matchesVersions reports whether any of the versions a file is built for
+// overlaps any of the wanted versions or ranges. No wanted versions
+// matches every file.
+func matchesVersions(wanted, have []string) bool {
+	if len(wanted) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(wanted, func(w string) bool {
+		return slices.ContainsFunc(have, func(h string) bool { return mcversion.Match(w, h) })
+	})
+}
+
+// exactVersions returns versions in canonical form if each of them names a
+// single version, as only those can be passed on to a site or API.
+func exactVersions(versions []string) ([]string, bool) {
+	exact := make([]string, 0, len(versions))
+	for _, v := range versions {
+		r, err := mcversion.ParseRange(v)
+		if err != nil || !r.IsExact() {
+			return nil, false
+		}
+		exact = append(exact, r.String())
+	}
+	return exact, true
+}
+
+var fileIDPattern = regexp.MustCompile(`/download/(\d+)`)
+
+// minecraftInsideFileID takes the file ID from a /download/<id>/ link.
+func minecraftInsideFileID(link string) string {
+	if m := fileIDPattern.FindStringSubmatch(link); m != nil {
+		return m[1]
+	}
+	return ""
 }
 
 func parseDownloadCount(title string) string {
@@ -96,17 +157,68 @@ func parseDownloadCount(title string) string {
 	return strings.TrimSpace(parts[1])
 }
 
+// cleanDescription tidies the teaser of a list entry: it collapses
+// whitespace and drops the mod name when the teaser merely repeats it.
 func cleanDescription(desc, name string) string {
-	desc = strings.TrimSpace(desc)
-	if strings.Contains(desc, name) {
-		desc = strings.Replace(desc, name+" добавляет", "", 1)
+	desc = strings.Join(strings.Fields(desc), " ")
+	if strings.EqualFold(desc, strings.TrimSpace(name)) {
+		return ""
+	}
+	return desc
+}
+
+// itemValue returns the value of the first element matching one of the
+// selectors, preferring the machine-readable content or datetime attribute
+// over the visible text.
+func itemValue(doc *goquery.Selection, selectors ...string) string {
+	for _, selector := range selectors {
+		s := doc.Find(selector).First()
+		if s.Length() == 0 {
+			continue
+		}
+		for _, attr := range []string{"content", "datetime"} {
+			if v, ok := s.Attr(attr); ok && strings.TrimSpace(v) != "" {
+				return strings.TrimSpace(v)
+			}
+		}
+		if text := strings.TrimSpace(s.Text()); text != "" {
+			return text
+		}
 	}
-	return strings.TrimSpace(desc)
+	return ""
+}
+
+func parseRating(text string) float64 {
+	rating, _ := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(text), ",", "."), 64)
+	return rating
 }
 
-func getString(m map[string]interface{}, key string) string {
-	if val, ok := m[key]; ok {
-		return val.(string)
+var sourceHosts = []string{"github.com", "gitlab.com", "codeberg.org", "bitbucket.org"}
+
+// add files link under the first kind of page it looks like, keeping the
+// links already found.
+func (l *ModLinks) add(link string) {
+	u, err := url.Parse(link)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return
 	}
-	return "0"
-}
\ No newline at end of file
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	path := strings.ToLower(u.Path)
+
+	var slot *string
+	switch {
+	case host == "discord.gg" || host == "discord.com" && strings.HasPrefix(path, "/invite"):
+		slot = &l.Discord
+	case slices.Contains(sourceHosts, host) && strings.Contains(path, "/issues"):
+		slot = &l.Issues
+	case strings.Contains(host, "wiki") || strings.Contains(path, "/wiki"):
+		slot = &l.Wiki
+	case slices.Contains(sourceHosts, host) && strings.Count(strings.Trim(path, "/"), "/") >= 1:
+		slot = &l.Source
+	default:
+		return
+	}
+	if *slot == "" {
+		*slot = link
+	}
+}
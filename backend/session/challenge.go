@@ -0,0 +1,199 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/lanxre/mc-launcher/backend/nettools"
+	"github.com/lanxre/mc-launcher/backend/operations"
+)
+
+const (
+	// EventChallenge asks the frontend to show a ChallengeRequest: the site,
+	// proxied by ChallengeProxy, in a frame of the app's webview.
+	EventChallenge = "session:challenge"
+	// EventChallengeDone tells the frontend the check of an operation is
+	// over, passed or not, so its frame can be closed.
+	EventChallengeDone = "session:challenge:done"
+
+	// ChallengePath is the asset server route the site is proxied under.
+	// CloudflarePath is proxied too, as the check loads its scripts from
+	// there at the root of the origin.
+	ChallengePath  = "/session/site/"
+	CloudflarePath = "/cdn-cgi/"
+
+	challengeTimeout = 5 * time.Minute
+)
+
+var ErrChallengeTimeout = errors.New("timeout: the Cloudflare check was not completed in time")
+
+type ChallengeRequest struct {
+	OpID string `yaml:"op_id"`
+	URL  string `yaml:"url"`
+}
+
+// RefreshClearance runs the Cloudflare check once in the app's webview. The
+// frontend opens the site through ChallengeProxy, which sees the clearance
+// cookie the page scripts cannot, and the call returns once it arrives.
+func (s *SessionService) RefreshClearance(opID string) error {
+	done := make(chan struct{})
+	s.mu.Lock()
+	s.pending = done
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		if s.pending == done {
+			s.pending = nil
+		}
+		s.mu.Unlock()
+		s.emit(EventChallengeDone, opID)
+	}()
+
+	return operations.Do(s.ops, opID, func(ctx context.Context) error {
+		s.emit(EventChallenge, ChallengeRequest{OpID: opID, URL: ChallengePath})
+		select {
+		case <-done:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(challengeTimeout):
+			return ErrChallengeTimeout
+		}
+	})
+}
+
+// harvest stores the user agent a clearance was issued for and completes
+// a pending RefreshClearance.
+func (s *SessionService) harvest(userAgent string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.state.UserAgent = userAgent
+	s.state.RefreshedAt = time.Now()
+	nettools.SetUserAgent(userAgent)
+	if err := saveState(s.state); err != nil {
+		log.Printf("⚠️ Failed to save session: %v", err)
+	}
+	if s.pending != nil {
+		close(s.pending)
+		s.pending = nil
+	}
+}
+
+// ChallengeProxy serves the scraped site on the app's own origin, so the
+// Cloudflare check can run in the webview while its cookies, HttpOnly ones
+// included, are stored in the shared jar instead of the webview's.
+type ChallengeProxy struct {
+	session *SessionService
+	client  *http.Client
+}
+
+func NewChallengeProxy(s *SessionService) *ChallengeProxy {
+	return &ChallengeProxy{
+		session: s,
+		client: &http.Client{
+			Timeout: 30 * time.Second,
+			Jar:     nettools.Jar(),
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// proxiedRequestHeaders are passed on from the webview. Cookies come from
+// the jar, never from the webview.
+var proxiedRequestHeaders = []string{"User-Agent", "Accept", "Accept-Language", "Content-Type", "Cache-Control"}
+
+// droppedResponseHeaders are not passed back: cookies stay in the jar, and
+// the site's framing and content policies would block the frame.
+var droppedResponseHeaders = []string{"Set-Cookie", "Content-Security-Policy", "X-Frame-Options", "Content-Length", "Content-Encoding"}
+
+func (p *ChallengeProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	target, ok := challengeTarget(r.URL)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	req, err := http.NewRequestWithContext(r.Context(), r.Method, target.String(), r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	for _, name := range proxiedRequestHeaders {
+		if value := r.Header.Get(name); value != "" {
+			req.Header.Set(name, value)
+		}
+	}
+	if referer, err := url.Parse(r.Referer()); err == nil {
+		if site, ok := challengeTarget(referer); ok {
+			req.Header.Set("Referer", site.String())
+		}
+	}
+	if r.Header.Get("Origin") != "" {
+		req.Header.Set("Origin", "https://"+clearanceHost)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer resp.Body.Close()
+
+	if hasClearance(resp) {
+		log.Printf("🔑 Cloudflare clearance received")
+		p.session.harvest(req.UserAgent())
+	}
+
+	for name, values := range resp.Header {
+		w.Header()[name] = values
+	}
+	for _, name := range droppedResponseHeaders {
+		w.Header().Del(name)
+	}
+	if loc, err := resp.Location(); err == nil && loc.Host == clearanceHost {
+		w.Header().Set("Location", proxiedURL(loc))
+	}
+	w.WriteHeader(resp.StatusCode)
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		log.Printf("⚠️ Failed to proxy %s: %v", target, err)
+	}
+}
+
+// challengeTarget returns the site URL a proxied request u stands for.
+func challengeTarget(u *url.URL) (*url.URL, bool) {
+	path := u.Path
+	if rest, ok := strings.CutPrefix(path, ChallengePath); ok {
+		path = "/" + rest
+	} else if !strings.HasPrefix(path, CloudflarePath) {
+		return nil, false
+	}
+	return &url.URL{Scheme: "https", Host: clearanceHost, Path: path, RawQuery: u.RawQuery}, true
+}
+
+// proxiedURL returns the path a site URL is proxied under.
+func proxiedURL(site *url.URL) string {
+	u := url.URL{Path: site.Path, RawQuery: site.RawQuery}
+	if !strings.HasPrefix(site.Path, CloudflarePath) {
+		u.Path = ChallengePath + strings.TrimPrefix(site.Path, "/")
+	}
+	return u.String()
+}
+
+func hasClearance(resp *http.Response) bool {
+	for _, c := range resp.Cookies() {
+		if c.Name == clearanceCookie && c.Value != "" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,177 @@
+package session
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/lanxre/mc-launcher/backend/appdata"
+	"github.com/lanxre/mc-launcher/backend/nettools"
+	"github.com/lanxre/mc-launcher/backend/operations"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	sessionFile = "session.yaml"
+
+	clearanceHost   = "minecraft-inside.ru"
+	clearanceCookie = "cf_clearance"
+)
+
+// ErrChallengeRequired is returned when the site answers with a Cloudflare
+// check instead of the page; RefreshClearance runs the check.
+var ErrChallengeRequired = errors.New("challenge_required: the site asks for a Cloudflare check, refresh the session")
+
+// Status describes the current session with the scraped site.
+type Status struct {
+	Profile          string    `yaml:"profile"`
+	UserAgent        string    `yaml:"user_agent"`
+	HasClearance     bool      `yaml:"has_clearance"`
+	ClearanceExpires time.Time `yaml:"clearance_expires"`
+	RefreshedAt      time.Time `yaml:"refreshed_at"`
+}
+
+// state is what the session keeps on disk besides the cookies: the user
+// agent the clearance was issued for, which must be sent along with it.
+type state struct {
+	UserAgent   string    `yaml:"user_agent"`
+	RefreshedAt time.Time `yaml:"refreshed_at"`
+}
+
+// SessionService manages the cookies and identity used for the scraped
+// site. The Cloudflare clearance cookie is HttpOnly, so page scripts cannot
+// read it from the webview: RefreshClearance runs the check through
+// ChallengeProxy, which keeps the cookies in the shared jar.
+type SessionService struct {
+	ops  *operations.Registry
+	emit func(name string, data ...interface{})
+
+	mu      sync.Mutex
+	state   state
+	pending chan struct{} // closed when a clearance arrives
+}
+
+func NewSessionService(ops *operations.Registry, emit func(name string, data ...interface{})) *SessionService {
+	s := &SessionService{ops: ops, emit: emit}
+	if s.emit == nil {
+		s.emit = func(string, ...interface{}) {}
+	}
+	st, err := loadState()
+	if err != nil {
+		log.Printf("⚠️ Failed to load session: %v", err)
+	}
+	s.state = st
+	nettools.SetUserAgent(st.UserAgent)
+	return s
+}
+
+func (s *SessionService) GetSession() Status {
+	s.mu.Lock()
+	st := s.state
+	s.mu.Unlock()
+
+	profile := nettools.CurrentProfile()
+	_, expires, ok := nettools.Jar().Lookup(clearanceHost, clearanceCookie)
+	return Status{
+		Profile:          profile.Name,
+		UserAgent:        profile.UserAgent,
+		HasClearance:     ok,
+		ClearanceExpires: expires,
+		RefreshedAt:      st.RefreshedAt,
+	}
+}
+
+// GetHeaderProfiles lists the built-in header profiles to choose from in
+// the settings.
+func (s *SessionService) GetHeaderProfiles() []nettools.HeaderProfile {
+	return nettools.Profiles()
+}
+
+// ImportCookies stores the cookies of a Cookie header for the scraped
+// site, along with the user agent of the browser they came from, for when
+// the check cannot be passed in the app.
+func (s *SessionService) ImportCookies(cookieHeader, userAgent string) error {
+	cookies, err := http.ParseCookie(cookieHeader)
+	if err != nil {
+		return fmt.Errorf("invalid cookies: %w", err)
+	}
+
+	site := &url.URL{Scheme: "https", Host: clearanceHost, Path: "/"}
+	for _, c := range cookies {
+		c.Domain = "." + clearanceHost
+		c.Path = "/"
+	}
+	nettools.Jar().SetCookies(site, cookies)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if userAgent != "" {
+		s.state.UserAgent = userAgent
+		nettools.SetUserAgent(userAgent)
+	}
+	s.state.RefreshedAt = time.Now()
+	return saveState(s.state)
+}
+
+// ClearSession forgets every cookie and the clearance user agent.
+func (s *SessionService) ClearSession() error {
+	if err := nettools.Jar().Clear(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state = state{}
+	nettools.SetUserAgent("")
+	return saveState(s.state)
+}
+
+func loadState() (state, error) {
+	var st state
+	path, err := statePath()
+	if err != nil {
+		return st, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return st, nil
+		}
+		return st, fmt.Errorf("failed to read session: %w", err)
+	}
+	if err := yaml.Unmarshal(data, &st); err != nil {
+		return state{}, fmt.Errorf("invalid session file %s: %w", path, err)
+	}
+	return st, nil
+}
+
+func saveState(st state) error {
+	path, err := statePath()
+	if err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(st)
+	if err != nil {
+		return fmt.Errorf("failed to marshal session: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write session: %w", err)
+	}
+	return nil
+}
+
+func statePath() (string, error) {
+	dir, err := appdata.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, sessionFile), nil
+}
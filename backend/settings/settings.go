@@ -0,0 +1,177 @@
+package settings
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/lanxre/mc-launcher/backend/appdata"
+	"github.com/lanxre/mc-launcher/backend/filetools"
+	"github.com/lanxre/mc-launcher/backend/nettools"
+	"github.com/lanxre/mc-launcher/backend/parser"
+	"gopkg.in/yaml.v3"
+)
+
+const settingsFile = "settings.yaml"
+
+type Settings struct {
+	Modrinth   ModrinthSettings   `yaml:"modrinth"`
+	CurseForge CurseForgeSettings `yaml:"curseforge"`
+	Catalogue  CatalogueSettings  `yaml:"catalogue"`
+	Downloads  DownloadSettings   `yaml:"downloads"`
+	Session    SessionSettings    `yaml:"session"`
+}
+
+type ModrinthSettings struct {
+	BaseURL string `yaml:"base_url"`
+}
+
+type CurseForgeSettings struct {
+	APIKey  string `yaml:"api_key"`
+	BaseURL string `yaml:"base_url"`
+}
+
+// CatalogueSettings controls the background crawl of the mod lists.
+type CatalogueSettings struct {
+	AutoCrawl bool `yaml:"auto_crawl"`
+}
+
+// DownloadSettings controls the download manager.
+type DownloadSettings struct {
+	Concurrency int `yaml:"concurrency"`
+}
+
+// SessionSettings picks the header profile sent to the scraped sites.
+// UserAgent and AcceptLanguage, when set, replace the profile's values.
+type SessionSettings struct {
+	Profile        string `yaml:"profile"`
+	UserAgent      string `yaml:"user_agent"`
+	AcceptLanguage string `yaml:"accept_language"`
+}
+
+// HeaderProfile returns the configured profile with the overrides applied.
+func (s SessionSettings) HeaderProfile() nettools.HeaderProfile {
+	p := nettools.LookupProfile(s.Profile)
+	if s.UserAgent != "" {
+		p.UserAgent = s.UserAgent
+	}
+	if s.AcceptLanguage != "" {
+		p.AcceptLanguage = s.AcceptLanguage
+	}
+	return p
+}
+
+func Default() Settings {
+	return Settings{
+		Modrinth:   ModrinthSettings{BaseURL: parser.DefaultModrinthBaseURL},
+		CurseForge: CurseForgeSettings{BaseURL: parser.DefaultCurseForgeBaseURL},
+		Downloads:  DownloadSettings{Concurrency: filetools.DefaultConcurrency},
+		Session:    SessionSettings{Profile: nettools.DefaultProfile},
+	}
+}
+
+func Load() (Settings, error) {
+	s := Default()
+
+	path, err := settingsPath()
+	if err != nil {
+		return s, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return s, nil
+		}
+		return s, fmt.Errorf("failed to read settings: %w", err)
+	}
+
+	if err := yaml.Unmarshal(data, &s); err != nil {
+		return Default(), fmt.Errorf("invalid settings file %s: %w", path, err)
+	}
+	s.applyDefaults()
+	return s, nil
+}
+
+func Save(s Settings) error {
+	path, err := settingsPath()
+	if err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("failed to marshal settings: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write settings: %w", err)
+	}
+	return nil
+}
+
+func (s *Settings) applyDefaults() {
+	d := Default()
+	if s.Modrinth.BaseURL == "" {
+		s.Modrinth.BaseURL = d.Modrinth.BaseURL
+	}
+	if s.CurseForge.BaseURL == "" {
+		s.CurseForge.BaseURL = d.CurseForge.BaseURL
+	}
+	if s.Session.Profile == "" {
+		s.Session.Profile = d.Session.Profile
+	}
+	if s.Downloads.Concurrency <= 0 {
+		s.Downloads.Concurrency = d.Downloads.Concurrency
+	}
+}
+
+func settingsPath() (string, error) {
+	dir, err := appdata.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, settingsFile), nil
+}
+
+type SettingsService struct {
+	mu       sync.Mutex
+	current  Settings
+	onChange []func(Settings)
+}
+
+func NewSettingsService(initial Settings) *SettingsService {
+	return &SettingsService{current: initial}
+}
+
+// OnChange registers fn to be called with the new settings after every
+// successful SaveSettings.
+func (s *SettingsService) OnChange(fn func(Settings)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onChange = append(s.onChange, fn)
+}
+
+func (s *SettingsService) GetSettings() Settings {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.current
+}
+
+func (s *SettingsService) SaveSettings(updated Settings) error {
+	updated.applyDefaults()
+	if err := Save(updated); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.current = updated
+	listeners := append([]func(Settings){}, s.onChange...)
+	s.mu.Unlock()
+
+	for _, fn := range listeners {
+		fn(updated)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+import {
+	DownloadsMods,
+	ResolveDownloadCollision,
+} from "@wailsjs/go/filetools/FileService";
+import { filetools } from "@wailsjs/go/models";
+import { EventsOn } from "@wailsjs/runtime/runtime";
+import type { ModFile } from "@/types";
+
+const EventDownloadUpdate = "download:update";
+// States a download leaves only when the user or the network acts: waiting
+// downloads resume by themselves once the network is back.
+const settledStates = ["done", "failed", "cancelled", "waiting"];
+
+export const modDownload = (name: string, file: ModFile) =>
+	filetools.ModDownload.createFrom({ Name: name, File: file });
+
+// isNameCollision reports whether d failed because a different file with
+// its name is already installed.
+export const isNameCollision = (d: filetools.Download): boolean =>
+	d.State === "failed" && d.Error.startsWith("name_collision:");
+
+// settle runs start, which queues downloads and returns their IDs, and
+// resolves once every one of them is done, failed, cancelled or waiting
+// for the network.
+const settle = async (
+	start: () => Promise<string[]>,
+): Promise<filetools.Download[]> => {
+	const finished = new Map<string, filetools.Download>();
+	let ids: string[] | null = null;
+	let resolveAll: (downloads: filetools.Download[]) => void = () => {};
+	const allFinished = new Promise<filetools.Download[]>((resolve) => {
+		resolveAll = resolve;
+	});
+
+	const check = () => {
+		if (ids?.every((id) => finished.has(id))) {
+			resolveAll(ids.map((id) => finished.get(id) as filetools.Download));
+		}
+	};
+
+	// Listen before queueing so no update is missed.
+	const off = EventsOn(EventDownloadUpdate, (d: filetools.Download) => {
+		if (settledStates.includes(d.State)) {
+			finished.set(d.ID, d);
+			check();
+		}
+	});
+
+	try {
+		ids = await start();
+		check();
+		return await allFinished;
+	} finally {
+		off();
+	}
+};
+
+// downloadMods queues mods with the download manager and resolves with
+// their downloads once every one of them has settled.
+export const downloadMods = (
+	mods: filetools.ModDownload[],
+): Promise<filetools.Download[]> =>
+	settle(async () => (await DownloadsMods(mods)).map((d) => d.ID));
+
+// resolveCollision retries a download that failed with a name collision,
+// replacing the installed file or keeping both, and resolves with the
+// download once it has settled again.
+export const resolveCollision = async (
+	d: filetools.Download,
+	policy: "replace" | "keep_both",
+): Promise<filetools.Download> => {
+	const [settled] = await settle(async () => {
+		await ResolveDownloadCollision(d.ID, policy);
+		return [d.ID];
+	});
+	return settled;
+};
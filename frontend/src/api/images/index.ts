@@ -0,0 +1,9 @@
+// Route the backend serves remote images on, see nettools.ImageProxy.
+const imageProxyPath = "/cache/image";
+
+// cachedImage loads a remote image through the backend, so it is cached
+// with the pages and still shows offline.
+export const cachedImage = (url: string): string =>
+	url?.startsWith("http")
+		? `${imageProxyPath}?url=${encodeURIComponent(url)}`
+		: url;
@@ -0,0 +1,18 @@
+import { CancelOperation } from "@wailsjs/go/main/App";
+
+// Operation IDs passed to the backend. Starting an operation cancels the
+// one still running under the same ID.
+export const Operation = {
+	ModsList: "mods-list",
+	ModDetails: "mod-details",
+	ModDepends: "mod-depends",
+	DependencyDetails: "dependency-details",
+	Clearance: "session-clearance",
+} as const;
+
+export const cancelOperation = async (id: string) => {
+	await CancelOperation(id);
+};
+
+export const isCancelled = (err: unknown): boolean =>
+	String(err).startsWith("cancelled:");
@@ -4,6 +4,7 @@ import {
 } from "@wailsjs/go/functools/FuncService";
 import { OpenExternalLink } from "@wailsjs/go/main/App";
 import { GetModDetails } from "@wailsjs/go/parser/ScraperService";
+import { Operation } from "@/api/operations";
 import type { MinecraftMod, ModDependency, PairDepend } from "@/types";
 
 export const uniqueBy = <T>(array: T[], keyFn: (item: T) => string): T[] => {
@@ -91,7 +92,11 @@ export const filterNoDiskModDepends = async (depends: ModDependency[]) => {
 export async function enrichDependencies(deps: ModDependency[], versions: string[]) {
 	for (const dep of deps) {
 		if (dep.Details == null || dep.Details.length === 0) {
-			const data = await GetModDetails(dep.ModPageLink, versions);
+			const data = await GetModDetails(
+				Operation.DependencyDetails,
+				dep.ModPageLink,
+				versions,
+			);
 			dep.Details = data.Details;
 		}
 	}
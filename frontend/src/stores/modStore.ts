@@ -31,7 +31,9 @@ export const useModStore = defineStore("mod", {
 		getModsByLoader: (state) => (loader: string) => {
 			return state.allMods.filter((mod) =>
 				mod.Details?.some((detail) =>
-					detail.Loader.toLowerCase().includes(loader.toLowerCase()),
+					detail.Loaders?.some((l) =>
+						l.toLowerCase().includes(loader.toLowerCase()),
+					),
 				),
 			);
 		},
@@ -1,29 +1,7 @@
-export interface ModDependency {
-	ModPageLink: string;
-	Name: string;
-	Dependency: ModDependency[];
-	Details: DownloadInfo[];
+import type { parser } from "@wailsjs/go/models";
 
-	convertValues: any;
-}
-
-export interface DownloadInfo {
-	URL: string;
-	Version: string;
-	Loader: string;
-	Downloads: string;
-}
-
-export interface MinecraftMod {
-	Name: string;
-	Icon: string;
-	ModPageLink: string;
-	Description: string;
-	Versions: string[];
-	Screenshots: string[];
-	Loaders: string[];
-	Dependency: ModDependency[];
-	Details: DownloadInfo[];
-
-	convertValues: any;
-}
+// The mod types are the ones generated from the backend, so the two
+// cannot drift apart.
+export type ModDependency = parser.ModDependency;
+export type ModFile = parser.ModFile;
+export type MinecraftMod = parser.MinecraftMod;
@@ -1,9 +1,37 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
-import {parser} from '../models';
+import {filetools} from '../models';
 
-export function DownloadFileToMinecraftMods(arg1:string,arg2:string):Promise<void>;
+export function CancelDownload(arg1:string):Promise<void>;
 
-export function DownloadsMods(arg1:Array<string>,arg2:Array<parser.DownloadInfo>):Promise<void>;
+export function ClearFinishedDownloads():Promise<void>;
+
+export function ClearPendingInstalls():Promise<void>;
+
+export function DownloadFileToMinecraftMods(arg1:string,arg2:string,arg3:string):Promise<void>;
+
+export function DownloadToCategory(arg1:string,arg2:string,arg3:string,arg4:string):Promise<void>;
+
+export function DownloadsMods(arg1:Array<filetools.ModDownload>):Promise<Array<filetools.Download>>;
+
+export function GetDownloads():Promise<Array<filetools.Download>>;
+
+export function GetPendingInstalls():Promise<Array<filetools.PendingInstall>>;
+
+export function PauseDownload(arg1:string):Promise<void>;
+
+export function QueueDownload(arg1:string,arg2:string,arg3:string):Promise<filetools.Download>;
 
 export function RemoveAllMods():Promise<void>;
+
+export function ResolveDownloadCollision(arg1:string,arg2:string):Promise<void>;
+
+export function ResumeDownload(arg1:string):Promise<void>;
+
+export function RetryDownload(arg1:string):Promise<void>;
+
+export function RunPendingInstalls(arg1:string):Promise<void>;
+
+export function SetDownloadConcurrency(arg1:number):Promise<void>;
+
+export function VerifyMods():Promise<Array<filetools.ModIntegrity>>;
@@ -2,14 +2,70 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
-export function DownloadFileToMinecraftMods(arg1, arg2) {
-  return window['go']['filetools']['FileService']['DownloadFileToMinecraftMods'](arg1, arg2);
+export function CancelDownload(arg1) {
+  return window['go']['filetools']['FileService']['CancelDownload'](arg1);
 }
 
-export function DownloadsMods(arg1, arg2) {
-  return window['go']['filetools']['FileService']['DownloadsMods'](arg1, arg2);
+export function ClearFinishedDownloads() {
+  return window['go']['filetools']['FileService']['ClearFinishedDownloads']();
+}
+
+export function ClearPendingInstalls() {
+  return window['go']['filetools']['FileService']['ClearPendingInstalls']();
+}
+
+export function DownloadFileToMinecraftMods(arg1, arg2, arg3) {
+  return window['go']['filetools']['FileService']['DownloadFileToMinecraftMods'](arg1, arg2, arg3);
+}
+
+export function DownloadToCategory(arg1, arg2, arg3, arg4) {
+  return window['go']['filetools']['FileService']['DownloadToCategory'](arg1, arg2, arg3, arg4);
+}
+
+export function DownloadsMods(arg1) {
+  return window['go']['filetools']['FileService']['DownloadsMods'](arg1);
+}
+
+export function GetDownloads() {
+  return window['go']['filetools']['FileService']['GetDownloads']();
+}
+
+export function GetPendingInstalls() {
+  return window['go']['filetools']['FileService']['GetPendingInstalls']();
+}
+
+export function PauseDownload(arg1) {
+  return window['go']['filetools']['FileService']['PauseDownload'](arg1);
+}
+
+export function QueueDownload(arg1, arg2, arg3) {
+  return window['go']['filetools']['FileService']['QueueDownload'](arg1, arg2, arg3);
 }
 
 export function RemoveAllMods() {
   return window['go']['filetools']['FileService']['RemoveAllMods']();
 }
+
+export function ResolveDownloadCollision(arg1, arg2) {
+  return window['go']['filetools']['FileService']['ResolveDownloadCollision'](arg1, arg2);
+}
+
+export function ResumeDownload(arg1) {
+  return window['go']['filetools']['FileService']['ResumeDownload'](arg1);
+}
+
+export function RetryDownload(arg1) {
+  return window['go']['filetools']['FileService']['RetryDownload'](arg1);
+}
+
+export function RunPendingInstalls(arg1) {
+  return window['go']['filetools']['FileService']['RunPendingInstalls'](arg1);
+}
+
+export function SetDownloadConcurrency(arg1) {
+  return window['go']['filetools']['FileService']['SetDownloadConcurrency'](arg1);
+}
+
+export function VerifyMods() {
+  return window['go']['filetools']['FileService']['VerifyMods']();
+}
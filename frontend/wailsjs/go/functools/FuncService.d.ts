@@ -2,8 +2,16 @@
 // This file is automatically generated. DO NOT EDIT
 import {parser} from '../models';
 
+export function ClearCache():Promise<void>;
+
+export function DeleteInstalledMod(arg1:string):Promise<void>;
+
 export function DeleteSavedMod(arg1:string):Promise<void>;
 
+export function GetCacheSize():Promise<number>;
+
+export function GetInstalledContent(arg1:string):Promise<Array<string>>;
+
 export function GetMinecraftVersions():Promise<Array<string>>;
 
 export function GetSavedMods():Promise<Array<string>>;
@@ -12,6 +20,8 @@ export function GetYamlConfig(arg1:string):Promise<Array<parser.MinecraftMod>>;
 
 export function IsModExist(arg1:string):Promise<boolean>;
 
+export function OpenCategoryFolder(arg1:string):Promise<void>;
+
 export function OpenModsFolder():Promise<void>;
 
 export function RemoveFromDownloads():Promise<void>;
@@ -23,3 +33,7 @@ export function SaveYamlModConfig(arg1:parser.MinecraftMod,arg2:string):Promise<
 export function SortByLoader(arg1:Array<parser.MinecraftMod>,arg2:string):Promise<Array<parser.MinecraftMod>>;
 
 export function SortByVersions(arg1:Array<parser.MinecraftMod>,arg2:string):Promise<Array<parser.MinecraftMod>>;
+
+export function SortFiles(arg1:Array<parser.ModFile>,arg2:parser.SortOrder):Promise<Array<parser.ModFile>>;
+
+export function SortMods(arg1:Array<parser.MinecraftMod>,arg2:parser.SortOrder):Promise<Array<parser.MinecraftMod>>;
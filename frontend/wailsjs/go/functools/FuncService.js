@@ -2,10 +2,26 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
+export function ClearCache() {
+  return window['go']['functools']['FuncService']['ClearCache']();
+}
+
+export function DeleteInstalledMod(arg1) {
+  return window['go']['functools']['FuncService']['DeleteInstalledMod'](arg1);
+}
+
 export function DeleteSavedMod(arg1) {
   return window['go']['functools']['FuncService']['DeleteSavedMod'](arg1);
 }
 
+export function GetCacheSize() {
+  return window['go']['functools']['FuncService']['GetCacheSize']();
+}
+
+export function GetInstalledContent(arg1) {
+  return window['go']['functools']['FuncService']['GetInstalledContent'](arg1);
+}
+
 export function GetMinecraftVersions() {
   return window['go']['functools']['FuncService']['GetMinecraftVersions']();
 }
@@ -22,6 +38,10 @@ export function IsModExist(arg1) {
   return window['go']['functools']['FuncService']['IsModExist'](arg1);
 }
 
+export function OpenCategoryFolder(arg1) {
+  return window['go']['functools']['FuncService']['OpenCategoryFolder'](arg1);
+}
+
 export function OpenModsFolder() {
   return window['go']['functools']['FuncService']['OpenModsFolder']();
 }
@@ -45,3 +65,11 @@ export function SortByLoader(arg1, arg2) {
 export function SortByVersions(arg1, arg2) {
   return window['go']['functools']['FuncService']['SortByVersions'](arg1, arg2);
 }
+
+export function SortFiles(arg1, arg2) {
+  return window['go']['functools']['FuncService']['SortFiles'](arg1, arg2);
+}
+
+export function SortMods(arg1, arg2) {
+  return window['go']['functools']['FuncService']['SortMods'](arg1, arg2);
+}
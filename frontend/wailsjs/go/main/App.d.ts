@@ -1,6 +1,10 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
+export function AskQuestion(arg1:string,arg2:string):Promise<boolean>;
+
+export function CancelOperation(arg1:string):Promise<boolean>;
+
 export function OpenExternalLink(arg1:string):Promise<void>;
 
 export function ShowInfoMessage(arg1:string,arg2:string):Promise<void>;
@@ -1,39 +1,1026 @@
+export namespace catalogue {
+	
+	export class CrawlStatus {
+	    Source: string;
+	    Running: boolean;
+	    NextPage: number;
+	    TotalPages: number;
+	    Mods: number;
+	    Complete: boolean;
+	    // Go type: time
+	    UpdatedAt: any;
+	    Error: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CrawlStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Source = source["Source"];
+	        this.Running = source["Running"];
+	        this.NextPage = source["NextPage"];
+	        this.TotalPages = source["TotalPages"];
+	        this.Mods = source["Mods"];
+	        this.Complete = source["Complete"];
+	        this.UpdatedAt = this.convertValues(source["UpdatedAt"], null);
+	        this.Error = source["Error"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace filetools {
+	
+	export class Download {
+	    ID: string;
+	    URL: string;
+	    Category: string;
+	    Filename: string;
+	    Hashes: Record<string, string>;
+	    Mod: string;
+	    OnCollision: string;
+	    Path: string;
+	    State: string;
+	    Received: number;
+	    Total: number;
+	    Speed: number;
+	    ETASeconds: number;
+	    Error: string;
+	    // Go type: time
+	    AddedAt: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new Download(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.ID = source["ID"];
+	        this.URL = source["URL"];
+	        this.Category = source["Category"];
+	        this.Filename = source["Filename"];
+	        this.Hashes = source["Hashes"];
+	        this.Mod = source["Mod"];
+	        this.OnCollision = source["OnCollision"];
+	        this.Path = source["Path"];
+	        this.State = source["State"];
+	        this.Received = source["Received"];
+	        this.Total = source["Total"];
+	        this.Speed = source["Speed"];
+	        this.ETASeconds = source["ETASeconds"];
+	        this.Error = source["Error"];
+	        this.AddedAt = this.convertValues(source["AddedAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ModDownload {
+	    Name: string;
+	    File: parser.ModFile;
+	    OnCollision: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ModDownload(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Name = source["Name"];
+	        this.File = this.convertValues(source["File"], parser.ModFile);
+	        this.OnCollision = source["OnCollision"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ModIntegrity {
+	    Path: string;
+	    Name: string;
+	    Status: string;
+	    SHA1: string;
+	    SHA512: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ModIntegrity(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Path = source["Path"];
+	        this.Name = source["Name"];
+	        this.Status = source["Status"];
+	        this.SHA1 = source["SHA1"];
+	        this.SHA512 = source["SHA512"];
+	    }
+	}
+	export class PendingInstall {
+	    URL: string;
+	    Category: string;
+	    Filename: string;
+	    Hashes: Record<string, string>;
+	    // Go type: time
+	    QueuedAt: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new PendingInstall(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.URL = source["URL"];
+	        this.Category = source["Category"];
+	        this.Filename = source["Filename"];
+	        this.Hashes = source["Hashes"];
+	        this.QueuedAt = this.convertValues(source["QueuedAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace nettools {
+	
+	export class ConnectivityStatus {
+	    Offline: boolean;
+	    // Go type: time
+	    Since: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new ConnectivityStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Offline = source["Offline"];
+	        this.Since = this.convertValues(source["Since"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class HeaderProfile {
+	    Name: string;
+	    UserAgent: string;
+	    Accept: string;
+	    AcceptLanguage: string;
+	    Extra: Record<string, string>;
+	
+	    static createFrom(source: any = {}) {
+	        return new HeaderProfile(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Name = source["Name"];
+	        this.UserAgent = source["UserAgent"];
+	        this.Accept = source["Accept"];
+	        this.AcceptLanguage = source["AcceptLanguage"];
+	        this.Extra = source["Extra"];
+	    }
+	}
+
+}
+
 export namespace parser {
 	
-	export class DownloadInfo {
-	    URL: string;
-	    Version: string;
-	    Loader: string;
-	    Downloads: string;
+	export class ChangelogEntry {
+	    File: ModFile;
+	    Changelog: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ChangelogEntry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.File = this.convertValues(source["File"], ModFile);
+	        this.Changelog = source["Changelog"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ModFile {
+	    Source: string;
+	    FileID: string;
+	    FileName: string;
+	    Versions: string[];
+	    Loaders: string[];
+	    URL: string;
+	    ManualURL: string;
+	    Size: string;
+	    SizeBytes: number;
+	    Date: string;
+	    // Go type: time
+	    Published: any;
+	    Downloads: string;
+	    DownloadCount: number;
+	    Hashes: Record<string, string>;
+	    Changelog: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ModFile(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Source = source["Source"];
+	        this.FileID = source["FileID"];
+	        this.FileName = source["FileName"];
+	        this.Versions = source["Versions"];
+	        this.Loaders = source["Loaders"];
+	        this.URL = source["URL"];
+	        this.ManualURL = source["ManualURL"];
+	        this.Size = source["Size"];
+	        this.SizeBytes = source["SizeBytes"];
+	        this.Date = source["Date"];
+	        this.Published = this.convertValues(source["Published"], null);
+	        this.Downloads = source["Downloads"];
+	        this.DownloadCount = source["DownloadCount"];
+	        this.Hashes = source["Hashes"];
+	        this.Changelog = source["Changelog"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Changelog {
+	    Installed: ModFile;
+	    Candidate: ModFile;
+	    Downgrade: boolean;
+	    Entries: ChangelogEntry[];
+	    Markdown: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Changelog(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Installed = this.convertValues(source["Installed"], ModFile);
+	        this.Candidate = this.convertValues(source["Candidate"], ModFile);
+	        this.Downgrade = source["Downgrade"];
+	        this.Entries = this.convertValues(source["Entries"], ChangelogEntry);
+	        this.Markdown = source["Markdown"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class ModDependency {
+	    Source: string;
+	    ModPageLink: string;
+	    Name: string;
+	    Dependency: ModDependency[];
+	    Details: ModFile[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ModDependency(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Source = source["Source"];
+	        this.ModPageLink = source["ModPageLink"];
+	        this.Name = source["Name"];
+	        this.Dependency = this.convertValues(source["Dependency"], ModDependency);
+	        this.Details = this.convertValues(source["Details"], ModFile);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class DependencyList {
+	    Items: ModDependency[];
+	    Stale: boolean;
+	    // Go type: time
+	    CachedAt: any;
+	    AgeSeconds: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new DependencyList(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Items = this.convertValues(source["Items"], ModDependency);
+	        this.Stale = source["Stale"];
+	        this.CachedAt = this.convertValues(source["CachedAt"], null);
+	        this.AgeSeconds = source["AgeSeconds"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Freshness {
+	    Stale: boolean;
+	    // Go type: time
+	    CachedAt: any;
+	    AgeSeconds: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Freshness(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Stale = source["Stale"];
+	        this.CachedAt = this.convertValues(source["CachedAt"], null);
+	        this.AgeSeconds = source["AgeSeconds"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ModLinks {
+	    Source: string;
+	    Issues: string;
+	    Wiki: string;
+	    Discord: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ModLinks(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Source = source["Source"];
+	        this.Issues = source["Issues"];
+	        this.Wiki = source["Wiki"];
+	        this.Discord = source["Discord"];
+	    }
+	}
+	export class MinecraftMod {
+	    Source: string;
+	    Name: string;
+	    Icon: string;
+	    ModPageLink: string;
+	    Description: string;
+	    Versions: string[];
+	    Screenshots: string[];
+	    Loaders: string[];
+	    Categories: string[];
+	    Downloads: number;
+	    // Go type: time
+	    Updated: any;
+	    Author: string;
+	    // Go type: time
+	    Published: any;
+	    Rating: number;
+	    Links: ModLinks;
+	    Body: string;
+	    Dependency: ModDependency[];
+	    Details: ModFile[];
+	    Freshness: Freshness;
+	
+	    static createFrom(source: any = {}) {
+	        return new MinecraftMod(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Source = source["Source"];
+	        this.Name = source["Name"];
+	        this.Icon = source["Icon"];
+	        this.ModPageLink = source["ModPageLink"];
+	        this.Description = source["Description"];
+	        this.Versions = source["Versions"];
+	        this.Screenshots = source["Screenshots"];
+	        this.Loaders = source["Loaders"];
+	        this.Categories = source["Categories"];
+	        this.Downloads = source["Downloads"];
+	        this.Updated = this.convertValues(source["Updated"], null);
+	        this.Author = source["Author"];
+	        this.Published = this.convertValues(source["Published"], null);
+	        this.Rating = source["Rating"];
+	        this.Links = this.convertValues(source["Links"], ModLinks);
+	        this.Body = source["Body"];
+	        this.Dependency = this.convertValues(source["Dependency"], ModDependency);
+	        this.Details = this.convertValues(source["Details"], ModFile);
+	        this.Freshness = this.convertValues(source["Freshness"], Freshness);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class FileIdentification {
+	    Matches: MinecraftMod[];
+	    Failures: Record<string, string>;
+	
+	    static createFrom(source: any = {}) {
+	        return new FileIdentification(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Matches = this.convertValues(source["Matches"], MinecraftMod);
+	        this.Failures = source["Failures"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class PlannedFile {
+	    Source: string;
+	    Name: string;
+	    ModPageLink: string;
+	    File: ModFile;
+	
+	    static createFrom(source: any = {}) {
+	        return new PlannedFile(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Source = source["Source"];
+	        this.Name = source["Name"];
+	        this.ModPageLink = source["ModPageLink"];
+	        this.File = this.convertValues(source["File"], ModFile);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ResolveTarget {
+	    Version: string;
+	    Loader: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ResolveTarget(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Version = source["Version"];
+	        this.Loader = source["Loader"];
+	    }
+	}
+	export class InstallPlan {
+	    Target: ResolveTarget;
+	    Tree: ModDependency;
+	    Files: PlannedFile[];
+	    Missing: ModDependency[];
+	    Cycles: string[][];
+	
+	    static createFrom(source: any = {}) {
+	        return new InstallPlan(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Target = this.convertValues(source["Target"], ResolveTarget);
+	        this.Tree = this.convertValues(source["Tree"], ModDependency);
+	        this.Files = this.convertValues(source["Files"], PlannedFile);
+	        this.Missing = this.convertValues(source["Missing"], ModDependency);
+	        this.Cycles = source["Cycles"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class MinecraftMap {
+	    Source: string;
+	    Category: string;
+	    Name: string;
+	    Icon: string;
+	    PageLink: string;
+	    Description: string;
+	    Versions: string[];
+	    Tags: string[];
+	    Screenshots: string[];
+	    Details: ModFile[];
+	    Genres: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new MinecraftMap(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Source = source["Source"];
+	        this.Category = source["Category"];
+	        this.Name = source["Name"];
+	        this.Icon = source["Icon"];
+	        this.PageLink = source["PageLink"];
+	        this.Description = source["Description"];
+	        this.Versions = source["Versions"];
+	        this.Tags = source["Tags"];
+	        this.Screenshots = source["Screenshots"];
+	        this.Details = this.convertValues(source["Details"], ModFile);
+	        this.Genres = source["Genres"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class MapsPage {
+	    Items: MinecraftMap[];
+	    Page: number;
+	    TotalPages: number;
+	    HasNext: boolean;
+	    TotalResults: number;
+	    Stale: boolean;
+	    // Go type: time
+	    CachedAt: any;
+	    AgeSeconds: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new MapsPage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Items = this.convertValues(source["Items"], MinecraftMap);
+	        this.Page = source["Page"];
+	        this.TotalPages = source["TotalPages"];
+	        this.HasNext = source["HasNext"];
+	        this.TotalResults = source["TotalResults"];
+	        this.Stale = source["Stale"];
+	        this.CachedAt = this.convertValues(source["CachedAt"], null);
+	        this.AgeSeconds = source["AgeSeconds"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+	
+	
+	export class ModFileList {
+	    Items: ModFile[];
+	    Stale: boolean;
+	    // Go type: time
+	    CachedAt: any;
+	    AgeSeconds: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ModFileList(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Items = this.convertValues(source["Items"], ModFile);
+	        this.Stale = source["Stale"];
+	        this.CachedAt = this.convertValues(source["CachedAt"], null);
+	        this.AgeSeconds = source["AgeSeconds"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class Modpack {
+	    Source: string;
+	    Category: string;
+	    Name: string;
+	    Icon: string;
+	    PageLink: string;
+	    Description: string;
+	    Versions: string[];
+	    Tags: string[];
+	    Screenshots: string[];
+	    Details: ModFile[];
+	    Loaders: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Modpack(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Source = source["Source"];
+	        this.Category = source["Category"];
+	        this.Name = source["Name"];
+	        this.Icon = source["Icon"];
+	        this.PageLink = source["PageLink"];
+	        this.Description = source["Description"];
+	        this.Versions = source["Versions"];
+	        this.Tags = source["Tags"];
+	        this.Screenshots = source["Screenshots"];
+	        this.Details = this.convertValues(source["Details"], ModFile);
+	        this.Loaders = source["Loaders"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ModpacksPage {
+	    Items: Modpack[];
+	    Page: number;
+	    TotalPages: number;
+	    HasNext: boolean;
+	    TotalResults: number;
+	    Stale: boolean;
+	    // Go type: time
+	    CachedAt: any;
+	    AgeSeconds: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ModpacksPage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Items = this.convertValues(source["Items"], Modpack);
+	        this.Page = source["Page"];
+	        this.TotalPages = source["TotalPages"];
+	        this.HasNext = source["HasNext"];
+	        this.TotalResults = source["TotalResults"];
+	        this.Stale = source["Stale"];
+	        this.CachedAt = this.convertValues(source["CachedAt"], null);
+	        this.AgeSeconds = source["AgeSeconds"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ModsPage {
+	    Items: MinecraftMod[];
+	    Page: number;
+	    TotalPages: number;
+	    HasNext: boolean;
+	    TotalResults: number;
+	    Stale: boolean;
+	    // Go type: time
+	    CachedAt: any;
+	    AgeSeconds: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new DownloadInfo(source);
+	        return new ModsPage(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.URL = source["URL"];
-	        this.Version = source["Version"];
-	        this.Loader = source["Loader"];
-	        this.Downloads = source["Downloads"];
+	        this.Items = this.convertValues(source["Items"], MinecraftMod);
+	        this.Page = source["Page"];
+	        this.TotalPages = source["TotalPages"];
+	        this.HasNext = source["HasNext"];
+	        this.TotalResults = source["TotalResults"];
+	        this.Stale = source["Stale"];
+	        this.CachedAt = this.convertValues(source["CachedAt"], null);
+	        this.AgeSeconds = source["AgeSeconds"];
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
-	export class ModDependency {
-	    ModPageLink: string;
+	
+	
+	export class ResourcePack {
+	    Source: string;
+	    Category: string;
 	    Name: string;
-	    Dependency: ModDependency[];
-	    Details: DownloadInfo[];
+	    Icon: string;
+	    PageLink: string;
+	    Description: string;
+	    Versions: string[];
+	    Tags: string[];
+	    Screenshots: string[];
+	    Details: ModFile[];
+	    Resolution: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new ModDependency(source);
+	        return new ResourcePack(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.ModPageLink = source["ModPageLink"];
+	        this.Source = source["Source"];
+	        this.Category = source["Category"];
 	        this.Name = source["Name"];
-	        this.Dependency = this.convertValues(source["Dependency"], ModDependency);
-	        this.Details = this.convertValues(source["Details"], DownloadInfo);
+	        this.Icon = source["Icon"];
+	        this.PageLink = source["PageLink"];
+	        this.Description = source["Description"];
+	        this.Versions = source["Versions"];
+	        this.Tags = source["Tags"];
+	        this.Screenshots = source["Screenshots"];
+	        this.Details = this.convertValues(source["Details"], ModFile);
+	        this.Resolution = source["Resolution"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -54,32 +1041,103 @@ export namespace parser {
 		    return a;
 		}
 	}
-	export class MinecraftMod {
+	export class ResourcePacksPage {
+	    Items: ResourcePack[];
+	    Page: number;
+	    TotalPages: number;
+	    HasNext: boolean;
+	    TotalResults: number;
+	    Stale: boolean;
+	    // Go type: time
+	    CachedAt: any;
+	    AgeSeconds: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ResourcePacksPage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Items = this.convertValues(source["Items"], ResourcePack);
+	        this.Page = source["Page"];
+	        this.TotalPages = source["TotalPages"];
+	        this.HasNext = source["HasNext"];
+	        this.TotalResults = source["TotalResults"];
+	        this.Stale = source["Stale"];
+	        this.CachedAt = this.convertValues(source["CachedAt"], null);
+	        this.AgeSeconds = source["AgeSeconds"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SearchQuery {
+	    Text: string;
+	    Page: number;
+	    Versions: string[];
+	    Loaders: string[];
+	    Category: string;
+	    Sort: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SearchQuery(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Text = source["Text"];
+	        this.Page = source["Page"];
+	        this.Versions = source["Versions"];
+	        this.Loaders = source["Loaders"];
+	        this.Category = source["Category"];
+	        this.Sort = source["Sort"];
+	    }
+	}
+	export class ShaderPack {
+	    Source: string;
+	    Category: string;
 	    Name: string;
 	    Icon: string;
-	    ModPageLink: string;
+	    PageLink: string;
 	    Description: string;
 	    Versions: string[];
+	    Tags: string[];
 	    Screenshots: string[];
+	    Details: ModFile[];
 	    Loaders: string[];
-	    Dependency: ModDependency[];
-	    Details: DownloadInfo[];
 	
 	    static createFrom(source: any = {}) {
-	        return new MinecraftMod(source);
+	        return new ShaderPack(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Source = source["Source"];
+	        this.Category = source["Category"];
 	        this.Name = source["Name"];
 	        this.Icon = source["Icon"];
-	        this.ModPageLink = source["ModPageLink"];
+	        this.PageLink = source["PageLink"];
 	        this.Description = source["Description"];
 	        this.Versions = source["Versions"];
+	        this.Tags = source["Tags"];
 	        this.Screenshots = source["Screenshots"];
+	        this.Details = this.convertValues(source["Details"], ModFile);
 	        this.Loaders = source["Loaders"];
-	        this.Dependency = this.convertValues(source["Dependency"], ModDependency);
-	        this.Details = this.convertValues(source["Details"], DownloadInfo);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -100,29 +1158,218 @@ export namespace parser {
 		    return a;
 		}
 	}
-	export class MinecraftModDetails {
-	    file_id: string;
-	    minecraft: string;
-	    loader: string;
-	    date: string;
-	    size: string;
-	    downloads: string;
-	    download_url: string;
+	export class ShaderPacksPage {
+	    Items: ShaderPack[];
+	    Page: number;
+	    TotalPages: number;
+	    HasNext: boolean;
+	    TotalResults: number;
+	    Stale: boolean;
+	    // Go type: time
+	    CachedAt: any;
+	    AgeSeconds: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new MinecraftModDetails(source);
+	        return new ShaderPacksPage(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.file_id = source["file_id"];
-	        this.minecraft = source["minecraft"];
-	        this.loader = source["loader"];
-	        this.date = source["date"];
-	        this.size = source["size"];
-	        this.downloads = source["downloads"];
-	        this.download_url = source["download_url"];
+	        this.Items = this.convertValues(source["Items"], ShaderPack);
+	        this.Page = source["Page"];
+	        this.TotalPages = source["TotalPages"];
+	        this.HasNext = source["HasNext"];
+	        this.TotalResults = source["TotalResults"];
+	        this.Stale = source["Stale"];
+	        this.CachedAt = this.convertValues(source["CachedAt"], null);
+	        this.AgeSeconds = source["AgeSeconds"];
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SourceInfo {
+	    ID: string;
+	    Name: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SourceInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.ID = source["ID"];
+	        this.Name = source["Name"];
+	    }
+	}
+
+}
+
+export namespace session {
+	
+	export class Status {
+	    Profile: string;
+	    UserAgent: string;
+	    HasClearance: boolean;
+	    // Go type: time
+	    ClearanceExpires: any;
+	    // Go type: time
+	    RefreshedAt: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new Status(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Profile = source["Profile"];
+	        this.UserAgent = source["UserAgent"];
+	        this.HasClearance = source["HasClearance"];
+	        this.ClearanceExpires = this.convertValues(source["ClearanceExpires"], null);
+	        this.RefreshedAt = this.convertValues(source["RefreshedAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace settings {
+	
+	export class CatalogueSettings {
+	    AutoCrawl: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new CatalogueSettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.AutoCrawl = source["AutoCrawl"];
+	    }
+	}
+	export class CurseForgeSettings {
+	    APIKey: string;
+	    BaseURL: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CurseForgeSettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.APIKey = source["APIKey"];
+	        this.BaseURL = source["BaseURL"];
+	    }
+	}
+	export class DownloadSettings {
+	    Concurrency: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new DownloadSettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Concurrency = source["Concurrency"];
+	    }
+	}
+	export class ModrinthSettings {
+	    BaseURL: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ModrinthSettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.BaseURL = source["BaseURL"];
+	    }
+	}
+	export class SessionSettings {
+	    Profile: string;
+	    UserAgent: string;
+	    AcceptLanguage: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SessionSettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Profile = source["Profile"];
+	        this.UserAgent = source["UserAgent"];
+	        this.AcceptLanguage = source["AcceptLanguage"];
+	    }
+	}
+	export class Settings {
+	    Modrinth: ModrinthSettings;
+	    CurseForge: CurseForgeSettings;
+	    Catalogue: CatalogueSettings;
+	    Downloads: DownloadSettings;
+	    Session: SessionSettings;
+	
+	    static createFrom(source: any = {}) {
+	        return new Settings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Modrinth = this.convertValues(source["Modrinth"], ModrinthSettings);
+	        this.CurseForge = this.convertValues(source["CurseForge"], CurseForgeSettings);
+	        this.Catalogue = this.convertValues(source["Catalogue"], CatalogueSettings);
+	        this.Downloads = this.convertValues(source["Downloads"], DownloadSettings);
+	        this.Session = this.convertValues(source["Session"], SessionSettings);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
 
 }
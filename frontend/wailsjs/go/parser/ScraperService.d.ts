@@ -1,17 +1,52 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 import {parser} from '../models';
+import {nettools} from '../models';
 
-export function GetMinecraftModDetailsV1(arg1:string):Promise<Array<parser.MinecraftModDetails>>;
+export function GetChangelogBetween(arg1:string,arg2:string,arg3:string,arg4:string,arg5:string):Promise<parser.Changelog>;
 
-export function GetModDepends(arg1:Array<parser.ModDependency>,arg2:Array<string>):Promise<Array<parser.ModDependency>>;
+export function GetConnectivity():Promise<nettools.ConnectivityStatus>;
 
-export function GetModDetails(arg1:string,arg2:Array<string>):Promise<parser.MinecraftMod>;
+export function GetMapDetails(arg1:string,arg2:string,arg3:Array<string>):Promise<parser.MinecraftMap>;
 
-export function GetMods():Promise<Array<parser.MinecraftMod>>;
+export function GetMaps(arg1:string,arg2:number,arg3:any):Promise<parser.MapsPage>;
 
-export function GetModsByPage(arg1:number,arg2:any):Promise<Array<parser.MinecraftMod>>;
+export function GetModDepends(arg1:string,arg2:Array<parser.ModDependency>,arg3:Array<string>):Promise<parser.DependencyList>;
 
-export function GetSearchMods(arg1:string,arg2:number):Promise<Array<parser.MinecraftMod>>;
+export function GetModDetails(arg1:string,arg2:string,arg3:Array<string>):Promise<parser.MinecraftMod>;
 
-export function GetTextureByPage(arg1:number,arg2:any):Promise<Array<parser.MinecraftMod>>;
+export function GetModpackDetails(arg1:string,arg2:string,arg3:Array<string>):Promise<parser.Modpack>;
+
+export function GetModpacks(arg1:string,arg2:number,arg3:any):Promise<parser.ModpacksPage>;
+
+export function GetMods(arg1:string):Promise<parser.ModsPage>;
+
+export function GetModsByPage(arg1:string,arg2:number,arg3:any):Promise<parser.ModsPage>;
+
+export function GetResourcePackDetails(arg1:string,arg2:string,arg3:Array<string>):Promise<parser.ResourcePack>;
+
+export function GetResourcePacks(arg1:string,arg2:number,arg3:any):Promise<parser.ResourcePacksPage>;
+
+export function GetSearchMods(arg1:string,arg2:string,arg3:number):Promise<parser.ModsPage>;
+
+export function GetShaderPackDetails(arg1:string,arg2:string,arg3:Array<string>):Promise<parser.ShaderPack>;
+
+export function GetShaderPacks(arg1:string,arg2:number,arg3:any):Promise<parser.ShaderPacksPage>;
+
+export function GetSourceModDepends(arg1:string,arg2:string,arg3:Array<parser.ModDependency>,arg4:Array<string>):Promise<parser.DependencyList>;
+
+export function GetSourceModDetails(arg1:string,arg2:string,arg3:string,arg4:Array<string>):Promise<parser.MinecraftMod>;
+
+export function GetSourceModFiles(arg1:string,arg2:string,arg3:string):Promise<parser.ModFileList>;
+
+export function GetSourceModsByPage(arg1:string,arg2:string,arg3:number,arg4:any):Promise<parser.ModsPage>;
+
+export function GetSources():Promise<Array<parser.SourceInfo>>;
+
+export function IdentifyModFile(arg1:string,arg2:string):Promise<parser.FileIdentification>;
+
+export function RegisterSource(arg1:parser.ModSource):Promise<void>;
+
+export function ResolveInstallPlan(arg1:string,arg2:string,arg3:string,arg4:string,arg5:parser.ResolveTarget):Promise<parser.InstallPlan>;
+
+export function SearchSourceMods(arg1:string,arg2:string,arg3:parser.SearchQuery):Promise<parser.ModsPage>;
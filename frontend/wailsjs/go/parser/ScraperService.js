@@ -2,30 +2,98 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
-export function GetMinecraftModDetailsV1(arg1) {
-  return window['go']['parser']['ScraperService']['GetMinecraftModDetailsV1'](arg1);
+export function GetChangelogBetween(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['parser']['ScraperService']['GetChangelogBetween'](arg1, arg2, arg3, arg4, arg5);
 }
 
-export function GetModDepends(arg1, arg2) {
-  return window['go']['parser']['ScraperService']['GetModDepends'](arg1, arg2);
+export function GetConnectivity() {
+  return window['go']['parser']['ScraperService']['GetConnectivity']();
 }
 
-export function GetModDetails(arg1, arg2) {
-  return window['go']['parser']['ScraperService']['GetModDetails'](arg1, arg2);
+export function GetMapDetails(arg1, arg2, arg3) {
+  return window['go']['parser']['ScraperService']['GetMapDetails'](arg1, arg2, arg3);
 }
 
-export function GetMods() {
-  return window['go']['parser']['ScraperService']['GetMods']();
+export function GetMaps(arg1, arg2, arg3) {
+  return window['go']['parser']['ScraperService']['GetMaps'](arg1, arg2, arg3);
 }
 
-export function GetModsByPage(arg1, arg2) {
-  return window['go']['parser']['ScraperService']['GetModsByPage'](arg1, arg2);
+export function GetModDepends(arg1, arg2, arg3) {
+  return window['go']['parser']['ScraperService']['GetModDepends'](arg1, arg2, arg3);
 }
 
-export function GetSearchMods(arg1, arg2) {
-  return window['go']['parser']['ScraperService']['GetSearchMods'](arg1, arg2);
+export function GetModDetails(arg1, arg2, arg3) {
+  return window['go']['parser']['ScraperService']['GetModDetails'](arg1, arg2, arg3);
 }
 
-export function GetTextureByPage(arg1, arg2) {
-  return window['go']['parser']['ScraperService']['GetTextureByPage'](arg1, arg2);
+export function GetModpackDetails(arg1, arg2, arg3) {
+  return window['go']['parser']['ScraperService']['GetModpackDetails'](arg1, arg2, arg3);
+}
+
+export function GetModpacks(arg1, arg2, arg3) {
+  return window['go']['parser']['ScraperService']['GetModpacks'](arg1, arg2, arg3);
+}
+
+export function GetMods(arg1) {
+  return window['go']['parser']['ScraperService']['GetMods'](arg1);
+}
+
+export function GetModsByPage(arg1, arg2, arg3) {
+  return window['go']['parser']['ScraperService']['GetModsByPage'](arg1, arg2, arg3);
+}
+
+export function GetResourcePackDetails(arg1, arg2, arg3) {
+  return window['go']['parser']['ScraperService']['GetResourcePackDetails'](arg1, arg2, arg3);
+}
+
+export function GetResourcePacks(arg1, arg2, arg3) {
+  return window['go']['parser']['ScraperService']['GetResourcePacks'](arg1, arg2, arg3);
+}
+
+export function GetSearchMods(arg1, arg2, arg3) {
+  return window['go']['parser']['ScraperService']['GetSearchMods'](arg1, arg2, arg3);
+}
+
+export function GetShaderPackDetails(arg1, arg2, arg3) {
+  return window['go']['parser']['ScraperService']['GetShaderPackDetails'](arg1, arg2, arg3);
+}
+
+export function GetShaderPacks(arg1, arg2, arg3) {
+  return window['go']['parser']['ScraperService']['GetShaderPacks'](arg1, arg2, arg3);
+}
+
+export function GetSourceModDepends(arg1, arg2, arg3, arg4) {
+  return window['go']['parser']['ScraperService']['GetSourceModDepends'](arg1, arg2, arg3, arg4);
+}
+
+export function GetSourceModDetails(arg1, arg2, arg3, arg4) {
+  return window['go']['parser']['ScraperService']['GetSourceModDetails'](arg1, arg2, arg3, arg4);
+}
+
+export function GetSourceModFiles(arg1, arg2, arg3) {
+  return window['go']['parser']['ScraperService']['GetSourceModFiles'](arg1, arg2, arg3);
+}
+
+export function GetSourceModsByPage(arg1, arg2, arg3, arg4) {
+  return window['go']['parser']['ScraperService']['GetSourceModsByPage'](arg1, arg2, arg3, arg4);
+}
+
+export function GetSources() {
+  return window['go']['parser']['ScraperService']['GetSources']();
+}
+
+export function IdentifyModFile(arg1, arg2) {
+  return window['go']['parser']['ScraperService']['IdentifyModFile'](arg1, arg2);
+}
+
+export function RegisterSource(arg1) {
+  return window['go']['parser']['ScraperService']['RegisterSource'](arg1);
+}
+
+export function ResolveInstallPlan(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['parser']['ScraperService']['ResolveInstallPlan'](arg1, arg2, arg3, arg4, arg5);
+}
+
+export function SearchSourceMods(arg1, arg2, arg3) {
+  return window['go']['parser']['ScraperService']['SearchSourceMods'](arg1, arg2, arg3);
 }
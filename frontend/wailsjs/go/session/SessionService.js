@@ -0,0 +1,23 @@
+// @ts-check
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+
+export function ClearSession() {
+  return window['go']['session']['SessionService']['ClearSession']();
+}
+
+export function GetHeaderProfiles() {
+  return window['go']['session']['SessionService']['GetHeaderProfiles']();
+}
+
+export function GetSession() {
+  return window['go']['session']['SessionService']['GetSession']();
+}
+
+export function ImportCookies(arg1, arg2) {
+  return window['go']['session']['SessionService']['ImportCookies'](arg1, arg2);
+}
+
+export function RefreshClearance(arg1) {
+  return window['go']['session']['SessionService']['RefreshClearance'](arg1);
+}
@@ -2,10 +2,16 @@ package main
 
 import (
 	"embed"
+	"net/http"
 
+	"github.com/lanxre/mc-launcher/backend/catalogue"
 	"github.com/lanxre/mc-launcher/backend/filetools"
 	"github.com/lanxre/mc-launcher/backend/functools"
+	"github.com/lanxre/mc-launcher/backend/nettools"
+	"github.com/lanxre/mc-launcher/backend/operations"
 	"github.com/lanxre/mc-launcher/backend/parser"
+	"github.com/lanxre/mc-launcher/backend/session"
+	"github.com/lanxre/mc-launcher/backend/settings"
 
 	"github.com/wailsapp/wails/v2"
 	"github.com/wailsapp/wails/v2/pkg/options"
@@ -17,25 +23,68 @@ import (
 var assets embed.FS
 
 func main() {
-	minecraftModsParser := parser.NewScraperService()
+	appSettings, err := settings.Load()
+	if err != nil {
+		println("Error:", err.Error())
+	}
+	settingsService := settings.NewSettingsService(appSettings)
+
+	ops := operations.NewRegistry()
+	app := NewApp(ops)
+
+	nettools.SetProfile(appSettings.Session.HeaderProfile())
+	settingsService.OnChange(func(updated settings.Settings) {
+		nettools.SetProfile(updated.Session.HeaderProfile())
+	})
+	sessionService := session.NewSessionService(ops, app.emit)
+
+	minecraftModsParser := parser.NewScraperService(ops, apiSources(appSettings)...)
+	settingsService.OnChange(func(updated settings.Settings) {
+		for _, src := range apiSources(updated) {
+			minecraftModsParser.RegisterSource(src)
+		}
+	})
+	catalogueService := catalogue.NewCatalogueService(minecraftModsParser, ops)
+	if appSettings.Catalogue.AutoCrawl {
+		for _, src := range minecraftModsParser.GetSources() {
+			if err := catalogueService.StartCrawl(src.ID); err != nil {
+				println("Error:", err.Error())
+			}
+		}
+	}
 	funcService := functools.NewFuncService()
-	fileService := filetools.NewFileService()
 
-	app := NewApp()
+	fileService := filetools.NewFileService(ops, filetools.DownloadOptions{
+		Concurrency: appSettings.Downloads.Concurrency,
+		Emit:        app.emit,
+	})
+	settingsService.OnChange(func(updated settings.Settings) {
+		fileService.SetDownloadConcurrency(updated.Downloads.Concurrency)
+	})
+	nettools.OnReconnect(func() {
+		if err := fileService.RunPendingInstalls("pending-installs"); err != nil {
+			println("Error:", err.Error())
+		}
+	})
 
-	err := wails.Run(&options.App{
+	err = wails.Run(&options.App{
 		Title:  "MC-LAUNCHER",
 		Width:  1024,
 		Height: 768,
 		AssetServer: &assetserver.Options{
-			Assets: assets,
+			Assets:  assets,
+			Handler: assetHandler(sessionService),
 		},
-		OnStartup: app.startup,
+		OnStartup:  app.startup,
+		OnShutdown: app.shutdown,
 		Bind: []interface{}{
 			app, 
 			minecraftModsParser, 
 			funcService,
 			fileService,
+			settingsService,
+			catalogueService,
+			sessionService,
 		},
 		Windows: &windows.Options{
 			WebviewIsTransparent:              true,
@@ -52,3 +101,21 @@ func main() {
 		println("Error:", err.Error())
 	}
 }
+
+func apiSources(s settings.Settings) []parser.ModSource {
+	return []parser.ModSource{
+		parser.NewModrinthSource(s.Modrinth.BaseURL),
+		parser.NewCurseForgeSource(s.CurseForge.BaseURL, s.CurseForge.APIKey),
+	}
+}
+
+// assetHandler serves what is not in the embedded assets: cached images and
+// the site proxied for the Cloudflare check.
+func assetHandler(s *session.SessionService) http.Handler {
+	challenge := session.NewChallengeProxy(s)
+	mux := http.NewServeMux()
+	mux.Handle(nettools.ImageProxyPath, nettools.NewImageProxy())
+	mux.Handle(session.ChallengePath, challenge)
+	mux.Handle(session.CloudflarePath, challenge)
+	return mux
+}